@@ -0,0 +1,115 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Circuit breaker defaults.
+const (
+	// DefaultBreakerThreshold is how many consecutive failures open a breaker.
+	DefaultBreakerThreshold = 3
+	// DefaultBreakerCooldown is how long an open breaker rejects requests
+	// before letting a trial request through.
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// Circuit breaker states.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// breaker is a consecutive-failure circuit breaker. It opens after
+// threshold failures in a row, lets a single trial request through once
+// cooldown has passed, and closes again on the first success. It is safe
+// for concurrent use.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	// trial is set while the single half-open request is in flight.
+	trial bool
+	now   func() time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may be sent, claiming the half-open trial
+// when the cooldown has passed.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.stateLocked() {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return false
+	}
+}
+
+// success closes the breaker.
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+}
+
+// failure records a failed request, opening the breaker once the threshold
+// is reached or when the half-open trial failed.
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= b.threshold || b.trial {
+		b.openedAt = b.now()
+	}
+	b.trial = false
+}
+
+// release gives up an allowed request without recording its outcome, so a
+// cancelled half-open trial does not keep the breaker open.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// state returns BreakerClosed, BreakerOpen or BreakerHalfOpen.
+func (b *breaker) state() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.stateLocked()
+}
+
+func (b *breaker) stateLocked() string {
+	if b.failures < b.threshold {
+		return BreakerClosed
+	}
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return BreakerOpen
+	}
+
+	return BreakerHalfOpen
+}
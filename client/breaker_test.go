@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var hits int32
+	var down atomic.Bool
+	down.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if down.Load() {
+			// Drop the connection like a crashed API
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	c := NewClient(server.URL)
+	c.SetCircuitBreaker(2, time.Minute)
+	c.breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetEngineers(); err == nil || errors.Is(err, ErrAPIUnavailable) {
+			t.Fatalf("request %d: expected a transport error, got %v", i, err)
+		}
+	}
+	if c.BreakerState() != BreakerOpen {
+		t.Fatalf("breaker is %s after 2 failures, want open", c.BreakerState())
+	}
+
+	// Open breakers fail fast without sending anything
+	if _, err := c.GetEngineers(); !errors.Is(err, ErrAPIUnavailable) {
+		t.Fatalf("expected ErrAPIUnavailable, got %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("server hits = %d, want 2", hits)
+	}
+
+	// After the cooldown a probe reaches the recovered API and closes it
+	down.Store(false)
+	now = now.Add(time.Minute)
+	if _, err := c.GetEngineers(); err != nil {
+		t.Fatalf("probe failed: %s", err)
+	}
+	if c.BreakerState() != BreakerClosed {
+		t.Errorf("breaker is %s after a successful probe, want closed", c.BreakerState())
+	}
+
+	// Error statuses come from a reachable API and never open the breaker
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "engineer not found", http.StatusNotFound)
+	}))
+	defer errorServer.Close()
+	c = NewClient(errorServer.URL)
+	c.SetCircuitBreaker(1, time.Minute)
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetEngineer("MISSING"); errors.Is(err, ErrAPIUnavailable) {
+			t.Fatalf("request %d failed fast on an error status", i)
+		}
+	}
+}
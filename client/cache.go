@@ -0,0 +1,89 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// readCache keeps GET response bodies for a bounded time and collapses
+// concurrent identical GETs into a single request. Writes invalidate the
+// entries they may have changed.
+type readCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generation is bumped on every invalidation so responses fetched
+	// before a write are never stored or shared after it.
+	generation uint64
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// get returns the cached body for key, calling fetch on a miss. Concurrent
+// misses for the same key share a single fetch.
+func (rc *readCache) get(key string, fetch func() ([]byte, error)) ([]byte, error) {
+	rc.mu.Lock()
+	entry, ok := rc.entries[key]
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.body, nil
+	}
+
+	flight := strconv.FormatUint(generation, 10) + " " + key
+	body, err, _ := rc.group.Do(flight, func() (interface{}, error) {
+		body, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+
+		rc.mu.Lock()
+		if rc.generation == generation {
+			rc.entries[key] = cacheEntry{body: body, expires: time.Now().Add(rc.ttl)}
+		}
+		rc.mu.Unlock()
+
+		return body, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return body.([]byte), nil
+}
+
+// invalidate drops the entries a write to path may have changed. Engineers
+// are embedded in devs, so engineer writes drop everything.
+func (rc *readCache) invalidate(path string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+
+	if !strings.HasPrefix(path, "/dev") {
+		rc.entries = map[string]cacheEntry{}
+		return
+	}
+	for key := range rc.entries {
+		if strings.HasPrefix(key, "/dev") {
+			delete(rc.entries, key)
+		}
+	}
+}
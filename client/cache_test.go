@@ -0,0 +1,116 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer serves empty JSON documents and counts requests per method.
+func countingServer(t *testing.T, delay time.Duration) (*httptest.Server, *int32, *int32) {
+	var gets, writes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&gets, 1)
+		} else {
+			atomic.AddInt32(&writes, 1)
+		}
+		time.Sleep(delay)
+		if r.URL.Path == "/engineers" || r.URL.Path == "/dev" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &gets, &writes
+}
+
+func TestReadCacheCollapsesConcurrentGets(t *testing.T) {
+	server, gets, _ := countingServer(t, 50*time.Millisecond)
+
+	c := NewClient(server.URL)
+	c.EnableReadCache(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetEngineer("G63RN"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := c.GetEngineer("G63RN"); err != nil {
+		t.Fatal(err)
+	}
+	if *gets != 1 {
+		t.Errorf("expected 1 GET to reach the server, got %d", *gets)
+	}
+}
+
+func TestReadCacheExpires(t *testing.T) {
+	server, gets, _ := countingServer(t, 0)
+
+	c := NewClient(server.URL)
+	c.EnableReadCache(20 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetDevs(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	if *gets != 2 {
+		t.Errorf("expected the expired entry to be fetched again, got %d GETs", *gets)
+	}
+}
+
+func TestReadCacheInvalidatedByWrites(t *testing.T) {
+	server, gets, _ := countingServer(t, 0)
+
+	c := NewClient(server.URL)
+	c.EnableReadCache(time.Minute)
+
+	read := func() {
+		t.Helper()
+		if _, err := c.GetEngineers(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetDevs(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read()
+	read()
+	if *gets != 2 {
+		t.Fatalf("expected 2 GETs before any write, got %d", *gets)
+	}
+
+	// A dev write only invalidates devs
+	if _, err := c.UpdateDev(Dev{Id: "D1"}); err != nil {
+		t.Fatal(err)
+	}
+	read()
+	if *gets != 3 {
+		t.Fatalf("expected only devs to be fetched again, got %d GETs", *gets)
+	}
+
+	// An engineer write invalidates engineers and the devs embedding them
+	if err := c.DeleteEngineer("G63RN"); err != nil {
+		t.Fatal(err)
+	}
+	read()
+	if *gets != 5 {
+		t.Fatalf("expected engineers and devs to be fetched again, got %d GETs", *gets)
+	}
+}
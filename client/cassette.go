@@ -0,0 +1,350 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Environment variables switching every new client to a cassette, so
+// acceptance tests can record API traffic once and replay it offline.
+const (
+	// CassetteEnv is the path of the cassette file.
+	CassetteEnv = "DEVOPS_BOOTCAMP_CASSETTE"
+	// CassetteModeEnv is CassetteRecord or CassetteReplay, defaulting to
+	// CassetteReplay.
+	CassetteModeEnv = "DEVOPS_BOOTCAMP_CASSETTE_MODE"
+)
+
+// Cassette modes.
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+)
+
+// Interaction is a recorded request and its response. Requests keep only
+// the method, path and body, and responses only their status, content type
+// and body, so hosts and credentials never end up in a cassette. Response
+// bodies are redacted of the engineers the test did not send itself.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized part of a request matched on replay.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized part of a response.
+type RecordedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// Cassette is an http.RoundTripper recording interactions to a file or
+// replaying them from it. Replayed requests match the first unused
+// interaction with the same method, path and body, so concurrent requests
+// replay correctly in any order. GETs that used up their interactions
+// replay the last one again, as Terraform versions differ in how often they
+// refresh. It is safe for concurrent use.
+type Cassette struct {
+	path string
+	mode string
+	// next sends requests while recording
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	// owned holds the lower-cased emails sent by the test while recording,
+	// which are kept in responses.
+	owned map[string]bool
+	// aliases replace the other emails found in responses.
+	aliases map[string]int
+}
+
+// emailPattern matches the email addresses redacted from cassettes.
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+var (
+	cassettesMu sync.Mutex
+	// cassettes are shared by path, so every provider instance Terraform
+	// starts during a test records to, or replays from, the same cassette.
+	cassettes = map[string]*Cassette{}
+)
+
+// OpenCassette returns the cassette at path, loading it when replaying.
+// Recording starts a new cassette that is saved after every interaction.
+func OpenCassette(path, mode string, next http.RoundTripper) (*Cassette, error) {
+	if mode != CassetteRecord && mode != CassetteReplay {
+		return nil, fmt.Errorf("unknown cassette mode %q, expected %s or %s", mode, CassetteRecord, CassetteReplay)
+	}
+
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+
+	if cassette, ok := cassettes[path]; ok && cassette.mode == mode {
+		return cassette, nil
+	}
+
+	cassette := &Cassette{path: path, mode: mode, next: next, owned: map[string]bool{}, aliases: map[string]int{}}
+	if mode == CassetteReplay {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette, record it with %s=%s: %w", CassetteModeEnv, CassetteRecord, err)
+		}
+		if err := json.Unmarshal(content, &cassette.interactions); err != nil {
+			return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+		}
+		cassette.used = make([]bool, len(cassette.interactions))
+	}
+	cassettes[path] = cassette
+
+	return cassette, nil
+}
+
+// CloseCassette forgets the cassette at path, so opening it again starts a
+// new recording or replays it from the start. Tests close their cassette
+// once done, so running them again in the same process, such as with
+// -count, does not see the interactions the earlier run used up.
+func CloseCassette(path string) {
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+
+	delete(cassettes, path)
+}
+
+// cassetteFromEnv wraps transport in the cassette configured by the
+// environment, or returns it unchanged when none is.
+func cassetteFromEnv(transport http.RoundTripper) http.RoundTripper {
+	path := os.Getenv(CassetteEnv)
+	if path == "" {
+		return transport
+	}
+
+	mode := os.Getenv(CassetteModeEnv)
+	if mode == "" {
+		mode = CassetteReplay
+	}
+
+	cassette, err := OpenCassette(path, mode, transport)
+	if err != nil {
+		return failingTransport{err}
+	}
+
+	return cassette
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := RecordedRequest{Method: req.Method, Path: req.URL.RequestURI()}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body = string(body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if c.mode == CassetteReplay {
+		return c.replay(req, recorded)
+	}
+
+	return c.record(req, recorded)
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+	for index, interaction := range c.interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+		if !c.used[index] {
+			match = index
+			break
+		}
+		if recorded.Method == http.MethodGet {
+			match = index
+		}
+	}
+
+	if match >= 0 {
+		c.used[match] = true
+		interaction := c.interactions[match]
+
+		header := http.Header{}
+		if interaction.Response.ContentType != "" {
+			header.Set("Content-Type", interaction.Response.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no unused interaction for %s %s, record it again with %s=%s",
+		c.path, recorded.Method, recorded.Path, CassetteModeEnv, CassetteRecord)
+}
+
+func (c *Cassette) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	res, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, email := range emailPattern.FindAllString(recorded.Body, -1) {
+		c.owned[strings.ToLower(email)] = true
+	}
+	c.interactions = append(c.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status:      res.StatusCode,
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        c.redactLocked(string(body)),
+		},
+	})
+
+	if err := c.saveLocked(); err != nil {
+		return nil, fmt.Errorf("saving cassette %s: %w", c.path, err)
+	}
+
+	return res, nil
+}
+
+// redactLocked replaces the emails in a response body that the test did not
+// send, and the names of the engineers they belong to, so cassettes recorded
+// against a shared API never hold other people's details. An email is always
+// replaced by the same alias, keeping listings coherent across interactions.
+// Callers must hold c.mu.
+func (c *Cassette) redactLocked(body string) string {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return emailPattern.ReplaceAllStringFunc(body, func(email string) string {
+			redacted, _ := c.aliasLocked(email)
+			return redacted
+		})
+	}
+
+	if !c.redactValueLocked(value) {
+		return body
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+
+	return string(redacted)
+}
+
+// redactValueLocked redacts a decoded JSON value in place and reports
+// whether anything was replaced. Callers must hold c.mu.
+func (c *Cassette) redactValueLocked(value interface{}) bool {
+	changed := false
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if email, ok := value["email"].(string); ok {
+			if redacted, alias := c.aliasLocked(email); alias > 0 {
+				value["email"] = redacted
+				if _, ok := value["name"].(string); ok {
+					value["name"] = fmt.Sprintf("redacted-%d", alias)
+				}
+				changed = true
+			}
+		}
+		for key, field := range value {
+			if text, ok := field.(string); ok && key != "email" {
+				redacted := emailPattern.ReplaceAllStringFunc(text, func(email string) string {
+					redacted, _ := c.aliasLocked(email)
+					return redacted
+				})
+				if redacted != text {
+					value[key] = redacted
+					changed = true
+				}
+				continue
+			}
+			changed = c.redactValueLocked(field) || changed
+		}
+	case []interface{}:
+		for _, element := range value {
+			changed = c.redactValueLocked(element) || changed
+		}
+	}
+
+	return changed
+}
+
+// aliasLocked returns the replacement for an email and its alias number, or
+// the email itself and 0 when the test sent it. Callers must hold c.mu.
+func (c *Cassette) aliasLocked(email string) (string, int) {
+	key := strings.ToLower(email)
+	if key == "" || c.owned[key] {
+		return email, 0
+	}
+
+	alias, ok := c.aliases[key]
+	if !ok {
+		alias = len(c.aliases) + 1
+		c.aliases[key] = alias
+	}
+
+	return fmt.Sprintf("redacted-%d@example.com", alias), alias
+}
+
+// saveLocked writes the recorded interactions. Callers must hold c.mu.
+func (c *Cassette) saveLocked() error {
+	content, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, append(content, '\n'), 0o644)
+}
+
+// failingTransport fails every request, for cassettes that could not be
+// opened when the client was created.
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
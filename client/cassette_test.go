@@ -0,0 +1,160 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "engineers.json")
+
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"G63RN","name":"sloane","email":"sloane@finches.com"}`))
+		default:
+			_, _ = w.Write([]byte(`[{"id":"G63RN","name":"sloane","email":"sloane@finches.com"}]`))
+		}
+	}))
+
+	t.Setenv(CassetteEnv, path)
+	t.Setenv(CassetteModeEnv, CassetteRecord)
+	recording := NewClient(server.URL)
+	if _, err := recording.CreateEngineer(Engineer{Name: "sloane", Email: "sloane@finches.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recording.GetEngineers(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "secret") || strings.Contains(string(content), server.Listener.Addr().String()) {
+		t.Errorf("cassette was not sanitized:\n%s", content)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(content, &interactions); err != nil {
+		t.Fatal(err)
+	}
+	want := RecordedRequest{Method: http.MethodPost, Path: "/engineers", Body: `{"name":"sloane","id":"","email":"sloane@finches.com"}`}
+	if len(interactions) != 2 || interactions[0].Request != want {
+		t.Fatalf("unexpected interactions: %+v", interactions)
+	}
+
+	// Replay offline, the server is gone
+	t.Setenv(CassetteModeEnv, CassetteReplay)
+	replaying := NewClient(server.URL)
+	engineer, err := replaying.CreateEngineer(Engineer{Name: "sloane", Email: "sloane@finches.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if engineer.Id != "G63RN" {
+		t.Errorf("replayed engineer %+v, want G63RN", engineer)
+	}
+
+	// GETs can be replayed more often than they were recorded
+	for i := 0; i < 2; i++ {
+		engineers, err := replaying.GetEngineers()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(engineers) != 1 {
+			t.Errorf("replayed %d engineers, want 1", len(engineers))
+		}
+	}
+
+	// Writes cannot
+	if _, err := replaying.CreateEngineer(Engineer{Name: "sloane", Email: "sloane@finches.com"}); err == nil || !strings.Contains(err.Error(), "no unused interaction for POST /engineers") {
+		t.Errorf("expected a missing interaction error, got %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("server hits = %d, want 2", hits)
+	}
+
+	// Closing the cassette replays it from the start again
+	CloseCassette(path)
+	if _, err := NewClient(server.URL).CreateEngineer(Engineer{Name: "sloane", Email: "sloane@finches.com"}); err != nil {
+		t.Errorf("expected the write to replay after closing the cassette, got %v", err)
+	}
+}
+
+func TestCassetteMissing(t *testing.T) {
+	t.Setenv(CassetteEnv, filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv(CassetteModeEnv, CassetteReplay)
+
+	_, err := NewClient("http://localhost:8080").GetEngineers()
+	if err == nil || !strings.Contains(err.Error(), CassetteModeEnv+"="+CassetteRecord) {
+		t.Errorf("expected an error explaining how to record, got %v", err)
+	}
+}
+
+func TestCassetteRedactsOtherEngineers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "engineers.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"id":"E1","name":"test","email":"test@test.com"}`))
+		default:
+			_, _ = w.Write([]byte(`[{"id":"G63RN","name":"sloane","email":"Sloane@Finches.com"},` +
+				`{"id":"E1","name":"test","email":"TEST@test.com"},` +
+				`{"id":"UWJVB","name":"ryan","email":"ryan@finches.com","labels":{"contact":"sloane@finches.com"}}]`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(CassetteEnv, path)
+	t.Setenv(CassetteModeEnv, CassetteRecord)
+	c := NewClient(server.URL)
+	if _, err := c.CreateEngineer(Engineer{Name: "test", Email: "test@test.com"}); err != nil {
+		t.Fatal(err)
+	}
+	// The live response is left untouched
+	engineers, err := c.GetEngineers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if engineers[0].Email != "Sloane@Finches.com" {
+		t.Errorf("recorded client got %s, want the live email", engineers[0].Email)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"sloane", "Sloane", "ryan"} {
+		if strings.Contains(string(content), leaked) {
+			t.Errorf("cassette leaks %q:\n%s", leaked, content)
+		}
+	}
+
+	// Replay sees the test's own engineer and consistent aliases for the rest
+	t.Setenv(CassetteModeEnv, CassetteReplay)
+	engineers, err = NewClient(server.URL).GetEngineers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Engineer{
+		{Id: "G63RN", Name: "redacted-1", Email: "redacted-1@example.com"},
+		{Id: "E1", Name: "test", Email: "TEST@test.com"},
+		{Id: "UWJVB", Name: "redacted-2", Email: "redacted-2@example.com", Labels: map[string]string{"contact": "redacted-1@example.com"}},
+	}
+	if !reflect.DeepEqual(engineers, want) {
+		t.Errorf("replayed %+v, want %+v", engineers, want)
+	}
+}
@@ -1,41 +1,238 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Client -
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
+	// Parallelism bounds the number of concurrent requests in bulk operations.
+	Parallelism int
+	// RateLimiter paces every request sent to the API.
+	RateLimiter *RateLimiter
+	// ReadOnly refuses every request but GET without sending it, see
+	// ErrReadOnly.
+	ReadOnly bool
+
+	// Server describes the API version and features, once Verify succeeded.
+	Server *ServerInfo
+
+	cache *readCache
+	hosts *hostPool
+	// sockets dials the Unix sockets socket hosts were resolved to
+	sockets *socketDialer
+	// breaker fails requests fast while the API keeps failing at the
+	// transport level, see ErrAPIUnavailable.
+	breaker *breaker
+	// ctx is attached to every request, see WithContext.
+	ctx context.Context
 }
 
-// NewClient initializes a new API client with the given host
+// NewClient initializes a new API client with the given host. Hosts of the
+// form unix:///path/to/sock or http+unix://%2Fpath%2Fto%2Fsock are reached
+// over that Unix domain socket.
 func NewClient(host string) *Client {
+	sockets := newSocketDialer()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = sockets.DialContext
+
 	return &Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		HostURL:    host,
+		HTTPClient:  &http.Client{Timeout: 10 * time.Second, Transport: cassetteFromEnv(transport)},
+		HostURL:     sockets.resolve(host),
+		Parallelism: DefaultParallelism,
+		RateLimiter: NewRateLimiter(0),
+		breaker:     newBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		sockets:     sockets,
 	}
 }
 
+// ErrAPIUnavailable is returned without sending the request while the
+// client's circuit breaker is open.
+var ErrAPIUnavailable = errors.New("DevOps Bootcamp API unavailable")
+
+// ErrReadOnly is returned without sending the request for any request but
+// GET while the client is read only.
+var ErrReadOnly = errors.New("DevOps Bootcamp client is read only")
+
+// SetCircuitBreaker opens the client's circuit breaker after threshold
+// consecutive transport failures and probes the API again after cooldown.
+// A threshold below 1 disables it.
+func (c *Client) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	if threshold < 1 {
+		c.breaker = nil
+		return
+	}
+	c.breaker = newBreaker(threshold, cooldown)
+}
+
+// BreakerState returns the state of the client's circuit breaker,
+// BreakerClosed when it is disabled.
+func (c *Client) BreakerState() string {
+	if c.breaker == nil {
+		return BreakerClosed
+	}
+
+	return c.breaker.state()
+}
+
+// EnableReadCache caches GET responses for ttl. Concurrent identical GETs
+// share one request, and writes invalidate the entries they affect.
+func (c *Client) EnableReadCache(ttl time.Duration) {
+	c.cache = newReadCache(ttl)
+}
+
+// StatusError is returned for responses with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// WithContext returns a copy of the client that sends its requests with ctx,
+// so they are cancelled with it and traced as its children. The copy shares
+// the HTTP client, rate limiter and read cache.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	copied := *c
+	copied.ctx = ctx
+
+	return &copied
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	res, err := c.HTTPClient.Do(req)
+	if c.ReadOnly && req.Method != http.MethodGet {
+		return nil, fmt.Errorf("%w, refusing %s %s", ErrReadOnly, req.Method, req.URL.Path)
+	}
+
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+
+	if c.cache == nil {
+		return c.fetch(req)
+	}
+
+	key := strings.TrimPrefix(req.URL.String(), c.HostURL)
+	if req.Method == http.MethodGet {
+		return c.cache.get(key, func() ([]byte, error) {
+			return c.fetch(req)
+		})
+	}
+
+	// Invalidate even when the write failed, it may have been applied
+	body, err := c.fetch(req)
+	c.cache.invalidate(key)
+
+	return body, err
+}
+
+// fetch sends the request and returns the response body of a successful call.
+func (c *Client) fetch(req *http.Request) (body []byte, err error) {
+	ctx, span := startSpan(req)
+	defer func() { endSpan(span, err) }()
+	req = req.WithContext(ctx)
+
+	res, err := c.sendThroughBreaker(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
 
-	body, err := io.ReadAll(res.Body)
+	body, err = io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
 
 	if (res.StatusCode != http.StatusOK) && (res.StatusCode != http.StatusCreated) {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, &StatusError{StatusCode: res.StatusCode, Body: body}
 	}
 
 	return body, err
 }
+
+// sendThroughBreaker sends the request unless the circuit breaker is open,
+// recording transport failures. Error statuses do not count as failures,
+// the API answered them.
+func (c *Client) sendThroughBreaker(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.sendWithFailover(req)
+	}
+
+	ctx := req.Context()
+	before := c.breaker.state()
+	if !c.breaker.allow() {
+		return nil, fmt.Errorf("%w: %d requests in a row failed, retrying after %s", ErrAPIUnavailable, c.breaker.threshold, c.breaker.cooldown)
+	}
+	if before == BreakerHalfOpen {
+		tflog.Info(ctx, "Probing devops-bootcamp api", map[string]interface{}{"circuit_breaker": BreakerHalfOpen})
+	}
+
+	res, err := c.sendWithFailover(req)
+	switch {
+	case err == nil:
+		c.breaker.success()
+	case errors.Is(err, context.Canceled):
+		// Terraform cancelled the operation, the API may be fine
+		c.breaker.release()
+	default:
+		c.breaker.failure()
+	}
+
+	if after := c.breaker.state(); after != before {
+		fields := map[string]interface{}{"circuit_breaker": after}
+		if after == BreakerOpen {
+			tflog.Warn(ctx, "DevOps Bootcamp API unavailable, failing requests fast", fields)
+		} else {
+			tflog.Info(ctx, "DevOps Bootcamp API circuit breaker changed state", fields)
+		}
+	}
+
+	return res, err
+}
+
+// send performs the request once the rate limiter allows it, retrying
+// requests the server rejected with 429 Too Many Requests.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.RateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		res, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		c.RateLimiter.Observe(res)
+
+		if res.StatusCode != http.StatusTooManyRequests || attempt == maxRateLimitRetries {
+			return res, nil
+		}
+
+		// Rewind the body before sending the request again
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return res, nil
+			}
+			req.Body = body
+		} else if req.Body != nil {
+			return res, nil
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}
+}
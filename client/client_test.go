@@ -0,0 +1,45 @@
+package client
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReadOnlyRefusesWrites(t *testing.T) {
+	server, gets, writes := countingServer(t, 0)
+	c := NewClient(server.URL)
+	c.ReadOnly = true
+
+	if _, err := c.GetEngineers(); err != nil {
+		t.Fatalf("GetEngineers() unexpected error: %s", err)
+	}
+	if _, err := c.GetDevs(); err != nil {
+		t.Fatalf("GetDevs() unexpected error: %s", err)
+	}
+
+	calls := map[string]func() error{
+		"CreateEngineer": func() error { _, err := c.CreateEngineer(Engineer{Name: "sloane"}); return err },
+		"UpdateEngineer": func() error { _, err := c.UpdateEngineer(Engineer{Id: "G63RN"}); return err },
+		"DeleteEngineer": func() error { return c.DeleteEngineer("G63RN") },
+		"CreateDev":      func() error { _, err := c.CreateDev(Dev{Name: "dev_finches"}); return err },
+		"UpdateDev":      func() error { _, err := c.UpdateDev(Dev{Id: "D1"}); return err },
+		"DeleteDev":      func() error { return c.DeleteDev("D1") },
+		"AddEngToDev":    func() error { return c.AddEngToDev("D1", "G63RN") },
+		"RemoveEngFromDev": func() error {
+			return c.RemoveEngFromDev("D1", "G63RN")
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s() error = %v, want ErrReadOnly", name, err)
+		}
+	}
+
+	if got := atomic.LoadInt32(writes); got != 0 {
+		t.Errorf("server received %d writes, want 0", got)
+	}
+	if got := atomic.LoadInt32(gets); got != 2 {
+		t.Errorf("server received %d gets, want 2", got)
+	}
+}
@@ -4,15 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
-
-	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch7/devops-resources"
 )
 
 // GetDev - Returns a single dev
-func (c *Client) GetDev(devID string) (*devops_resource.Dev, error) {
+func (c *Client) GetDev(devID string) (*Dev, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dev/id/%s", c.HostURL, devID), nil)
 	if err != nil {
 		return nil, err
@@ -23,7 +20,7 @@ func (c *Client) GetDev(devID string) (*devops_resource.Dev, error) {
 		return nil, err
 	}
 
-	dev := devops_resource.Dev{}
+	dev := Dev{}
 	err = json.Unmarshal(body, &dev)
 	if err != nil {
 		return nil, err
@@ -33,7 +30,7 @@ func (c *Client) GetDev(devID string) (*devops_resource.Dev, error) {
 }
 
 // GetDevs - Returns list of devs
-func (c *Client) GetDevs() ([]devops_resource.Dev, error) {
+func (c *Client) GetDevs() ([]Dev, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dev", c.HostURL), nil)
 	if err != nil {
 		return nil, err
@@ -44,7 +41,7 @@ func (c *Client) GetDevs() ([]devops_resource.Dev, error) {
 		return nil, err
 	}
 
-	devs := []devops_resource.Dev{}
+	devs := []Dev{}
 	err = json.Unmarshal(body, &devs)
 	if err != nil {
 		return nil, err
@@ -54,7 +51,7 @@ func (c *Client) GetDevs() ([]devops_resource.Dev, error) {
 }
 
 // CreateDev - Create a new Dev
-func (c *Client) CreateDev(dev devops_resource.Dev) (*devops_resource.Dev, error) {
+func (c *Client) CreateDev(dev Dev) (*Dev, error) {
 	// Marshal the single Dev into JSON
 	rb, err := json.Marshal(dev)
 	if err != nil {
@@ -74,7 +71,7 @@ func (c *Client) CreateDev(dev devops_resource.Dev) (*devops_resource.Dev, error
 	}
 
 	// Unmarshal the response into an Dev struct
-	devObj := devops_resource.Dev{}
+	devObj := Dev{}
 	err = json.Unmarshal(body, &devObj)
 	if err != nil {
 		return nil, err
@@ -84,29 +81,22 @@ func (c *Client) CreateDev(dev devops_resource.Dev) (*devops_resource.Dev, error
 }
 
 // UpdateDev - Update an existing dev
-func (c *Client) UpdateDev(dev devops_resource.Dev) (*devops_resource.Dev, error) {
-	log.Printf("\nUpdating dev: %+v\n", dev) // Add debug log
-
+func (c *Client) UpdateDev(dev Dev) (*Dev, error) {
 	// Marshal the single Dev into JSON
 	rb, err := json.Marshal(dev)
 	if err != nil {
-		log.Printf("\nError marshalling dev: %s\n", err) // Add debug log
 		return nil, err
 	}
 
 	// Create a new PUT request with the JSON body
 	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/dev/%s", c.HostURL, strings.Trim(dev.Id, "\"")), strings.NewReader(string(rb)))
 	if err != nil {
-		log.Printf("\nError creating request: %v\n", req) // Add debug log
-		log.Printf("\nError creating request: %s\n", err) // Add debug log
 		return nil, err
 	}
 
 	// Perform the HTTP request
 	body, err := c.doRequest(req)
-	log.Printf("\nResponse body: %s\n", body) // Add debug log
 	if err != nil {
-		log.Printf("\nError performing request: %s\n", err) // Add debug log
 		return nil, err
 	}
 
@@ -114,7 +104,6 @@ func (c *Client) UpdateDev(dev devops_resource.Dev) (*devops_resource.Dev, error
 	// an_dev := devops_resource.Dev{}
 	err = json.Unmarshal(body, &dev)
 	if err != nil {
-		log.Printf("\nError unmarshalling response: %s\n", err) // Add debug log
 		return nil, err
 	}
 
@@ -123,21 +112,15 @@ func (c *Client) UpdateDev(dev devops_resource.Dev) (*devops_resource.Dev, error
 
 // DeleteDev - Delete an existing dev
 func (c *Client) DeleteDev(id string) error {
-	log.Printf("\nDeleting dev: %+s\n", id) // Add debug log
-
 	// Create a new Delete request with the JSON body
 	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/dev/%s", c.HostURL, strings.Trim(id, "\"")), nil)
 	if err != nil {
-		log.Printf("\nError creating request: %v\n", req) // Add debug log
-		log.Printf("\nError creating request: %s\n", err) // Add debug log
 		return err
 	}
 
 	// Perform the HTTP request
-	body, err := c.doRequest(req)
-	log.Printf("\nResponse body: %s\n", body) // Add debug log
+	_, err = c.doRequest(req)
 	if err != nil {
-		log.Printf("\nError performing request: %s\n", err) // Add debug log
 		return err
 	}
 
@@ -173,7 +156,7 @@ func (c *Client) AddEngToDev(DevId string, EngId string) error {
 	}
 
 	// Unmarshal the response into an Dev struct
-	devObj := devops_resource.Dev{}
+	devObj := Dev{}
 	err = json.Unmarshal(body, &devObj)
 	if err != nil {
 		return err
@@ -181,3 +164,20 @@ func (c *Client) AddEngToDev(DevId string, EngId string) error {
 
 	return nil
 }
+
+// RemoveEngFromDev - removes engineer from dev engineers list
+func (c *Client) RemoveEngFromDev(DevId string, EngId string) error {
+	// Create a new DELETE request for the membership
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/dev/%s/%s", c.HostURL, DevId, EngId), nil)
+	if err != nil {
+		return err
+	}
+
+	// Perform the HTTP request
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -6,12 +6,10 @@ import (
 	"log"
 	"net/http"
 	"strings"
-
-	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch7/devops-resources"
 )
 
 // GetEngineer - Returns a single engineer
-func (c *Client) GetEngineer(engineerID string) (*devops_resource.Engineer, error) {
+func (c *Client) GetEngineer(engineerID string) (*Engineer, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/engineers/id/%s", c.HostURL, engineerID), nil)
 	if err != nil {
 		return nil, err
@@ -22,7 +20,7 @@ func (c *Client) GetEngineer(engineerID string) (*devops_resource.Engineer, erro
 		return nil, err
 	}
 
-	engineer := devops_resource.Engineer{}
+	engineer := Engineer{}
 	err = json.Unmarshal(body, &engineer)
 	if err != nil {
 		return nil, err
@@ -32,7 +30,7 @@ func (c *Client) GetEngineer(engineerID string) (*devops_resource.Engineer, erro
 }
 
 // GetEngineers - Returns list of engineers
-func (c *Client) GetEngineers() ([]devops_resource.Engineer, error) {
+func (c *Client) GetEngineers() ([]Engineer, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/engineers", c.HostURL), nil)
 	if err != nil {
 		return nil, err
@@ -43,7 +41,7 @@ func (c *Client) GetEngineers() ([]devops_resource.Engineer, error) {
 		return nil, err
 	}
 
-	engineers := []devops_resource.Engineer{}
+	engineers := []Engineer{}
 	err = json.Unmarshal(body, &engineers)
 	if err != nil {
 		return nil, err
@@ -53,7 +51,7 @@ func (c *Client) GetEngineers() ([]devops_resource.Engineer, error) {
 }
 
 // CreateEngineer - Create a new order with a single order item
-func (c *Client) CreateEngineer(engineer devops_resource.Engineer) (*devops_resource.Engineer, error) {
+func (c *Client) CreateEngineer(engineer Engineer) (*Engineer, error) {
 	// Marshal the single Engineer into JSON
 	rb, err := json.Marshal(engineer)
 	if err != nil {
@@ -73,7 +71,7 @@ func (c *Client) CreateEngineer(engineer devops_resource.Engineer) (*devops_reso
 	}
 
 	// Unmarshal the response into an Engineer struct
-	engineerObj := devops_resource.Engineer{}
+	engineerObj := Engineer{}
 	err = json.Unmarshal(body, &engineerObj)
 	if err != nil {
 		return nil, err
@@ -83,7 +81,7 @@ func (c *Client) CreateEngineer(engineer devops_resource.Engineer) (*devops_reso
 }
 
 // UpdateEngineer - Update an existing engineer
-func (c *Client) UpdateEngineer(engineer devops_resource.Engineer) (*devops_resource.Engineer, error) {
+func (c *Client) UpdateEngineer(engineer Engineer) (*Engineer, error) {
 	log.Printf("\nUpdating engineer: %+v\n", engineer) // Add debug log
 
 	// Marshal the single Engineer into JSON
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// bodyTransport answers every request with the same status and body.
+type bodyTransport struct {
+	status int
+	body   []byte
+}
+
+func (t bodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(string(t.body))),
+		Request:    req,
+	}, nil
+}
+
+func fuzzClient(t *testing.T, status int, body []byte) *Client {
+	if status == http.StatusTooManyRequests {
+		t.Skip("429 responses are retried with a backoff")
+	}
+
+	c := NewClient("http://fuzz.invalid")
+	c.HTTPClient = &http.Client{Transport: bodyTransport{status: status, body: body}}
+	c.SetCircuitBreaker(0, 0)
+
+	return c
+}
+
+func addDecodeSeeds(f *testing.F) {
+	f.Add(200, []byte(`{"id":"G63RN","name":"sloane","email":"sloane@finches.com"}`))
+	f.Add(200, []byte(`[{"id":"G63RN","name":"sloane","email":"sloane@finches.com","active":false}]`))
+	f.Add(200, []byte(`{"id":"D1","name":"dev_finches","engineers":[{"id":"G63RN"},null]}`))
+	f.Add(201, []byte(`[{"id":"D1","engineers":null}]`))
+	f.Add(200, []byte(`{"version":"1.2.0","features":["archive"]}`))
+	f.Add(200, []byte(`null`))
+	f.Add(404, []byte(`engineer not found`))
+	f.Add(200, []byte(`{"id":1}`))
+	f.Add(200, []byte(`{"id":"G63RN","role":"sre","level":"senior","skills":[]}`))
+}
+
+// FuzzDecodeEngineers checks that no response body makes the engineer
+// calls panic, and that decoded engineers survive a JSON round trip.
+func FuzzDecodeEngineers(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		c := fuzzClient(t, status, body)
+
+		if engineer, err := c.GetEngineer("G63RN"); err == nil {
+			assertRoundTrip(t, engineer)
+		}
+		if engineers, err := c.GetEngineers(); err == nil {
+			assertRoundTrip(t, engineers)
+		}
+		if engineer, err := c.UpdateEngineer(Engineer{Id: "G63RN"}); err == nil {
+			assertRoundTrip(t, engineer)
+		}
+		_, _ = c.CreateEngineers([]Engineer{{Name: "sloane"}, {Name: "ryan"}})
+	})
+}
+
+// FuzzDecodeDevs checks that no response body makes the dev calls panic,
+// including bodies with null engineers.
+func FuzzDecodeDevs(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		c := fuzzClient(t, status, body)
+
+		if dev, err := c.GetDev("D1"); err == nil {
+			assertMembers(t, *dev)
+			assertRoundTrip(t, dev)
+		}
+		if devs, err := c.GetDevs(); err == nil {
+			for _, dev := range devs {
+				assertMembers(t, dev)
+			}
+			assertRoundTrip(t, devs)
+		}
+		_, _ = c.AddEngsToDev("D1", []string{"G63RN", "UWJVB"})
+		_ = c.RemoveEngsFromDev("D1", []string{"G63RN"})
+	})
+}
+
+// FuzzDecodeServerInfo checks version negotiation against any response.
+func FuzzDecodeServerInfo(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		c := fuzzClient(t, status, body)
+
+		info, err := c.Verify()
+		if err != nil {
+			return
+		}
+		c.Server = info
+		for _, feature := range []string{FeatureArchive, FeatureRemoveEngineer, "unknown"} {
+			c.Supports(feature)
+		}
+	})
+}
+
+// assertMembers checks that every engineer of dev can be dereferenced.
+func assertMembers(t *testing.T, dev Dev) {
+	t.Helper()
+	for index, engineer := range dev.Engineers {
+		if engineer == nil {
+			t.Errorf("dev %q engineer %d is nil", dev.Id, index)
+		}
+	}
+}
+
+// assertRoundTrip checks that encoding a decoded value and decoding it again
+// gives the same value, so values written back to the API match the ones
+// that were read.
+func assertRoundTrip[T any](t *testing.T, value T) {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("encoding %#v: %s", value, err)
+	}
+
+	var decoded T
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("decoding %s: %s", encoded, err)
+	}
+	if !reflect.DeepEqual(decoded, value) {
+		t.Errorf("round trip changed %#v into %#v", value, decoded)
+	}
+}
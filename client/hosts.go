@@ -0,0 +1,213 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// hostPool spreads requests over a primary API and its standbys, with
+// optional read replicas for GETs. Requests stick to the host that last
+// answered, and hosts that keep failing are ejected by their breaker until
+// it lets a trial request through.
+type hostPool struct {
+	primaries []*poolHost
+	reads     []*poolHost
+
+	mu sync.Mutex
+	// sticky are the hosts that last answered, per group
+	stickyPrimary *poolHost
+	stickyRead    *poolHost
+}
+
+type poolHost struct {
+	// name is the host as configured, url the one requests are sent to
+	name    string
+	url     string
+	breaker *breaker
+}
+
+func newHostPool(hosts, readHosts []string, resolve func(string) string) *hostPool {
+	pool := &hostPool{}
+	for _, host := range hosts {
+		pool.primaries = append(pool.primaries, &poolHost{name: host, url: resolve(host), breaker: newBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown)})
+	}
+	for _, host := range readHosts {
+		pool.reads = append(pool.reads, &poolHost{name: host, url: resolve(host), breaker: newBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown)})
+	}
+	pool.stickyPrimary = pool.primaries[0]
+	if len(pool.reads) > 0 {
+		pool.stickyRead = pool.reads[0]
+	}
+
+	return pool
+}
+
+// EnableFailover sends requests to hosts, the primary first and standbys
+// in order, failing over when a host is unreachable or unavailable. GETs
+// go to readHosts first when any are given, falling back to hosts. The
+// first host becomes HostURL.
+func (c *Client) EnableFailover(hosts, readHosts []string) {
+	resolve := func(host string) string { return host }
+	if c.sockets != nil {
+		resolve = c.sockets.resolve
+	}
+
+	c.hosts = newHostPool(hosts, readHosts, resolve)
+	c.HostURL = c.hosts.primaries[0].url
+}
+
+// HostStates returns the breaker state of every host, keyed by the host as
+// configured.
+func (c *Client) HostStates() map[string]string {
+	states := map[string]string{}
+	if c.hosts == nil {
+		return states
+	}
+	for _, host := range append(append([]*poolHost{}, c.hosts.primaries...), c.hosts.reads...) {
+		states[host.name] = host.breaker.state()
+	}
+
+	return states
+}
+
+// candidates returns the hosts to try for a request in order: the sticky
+// host of each group first, and reads before primaries for GETs. Callers
+// skip the hosts whose breaker is open.
+func (p *hostPool) candidates(read bool) []*poolHost {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var ordered []*poolHost
+	if read && len(p.reads) > 0 {
+		ordered = append(ordered, stickyFirst(p.reads, p.stickyRead)...)
+	}
+	ordered = append(ordered, stickyFirst(p.primaries, p.stickyPrimary)...)
+
+	return ordered
+}
+
+// stick remembers the host that answered.
+func (p *hostPool) stick(host *poolHost) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, read := range p.reads {
+		if read == host {
+			p.stickyRead = host
+			return
+		}
+	}
+	p.stickyPrimary = host
+}
+
+func stickyFirst(hosts []*poolHost, sticky *poolHost) []*poolHost {
+	ordered := []*poolHost{sticky}
+	for _, host := range hosts {
+		if host != sticky {
+			ordered = append(ordered, host)
+		}
+	}
+
+	return ordered
+}
+
+// sendWithFailover sends the request to the first healthy host that
+// answers. Reads fail over on any transport error or unavailable status.
+// Writes only fail over when the connection could not be made, as they may
+// otherwise have been applied.
+func (c *Client) sendWithFailover(req *http.Request) (*http.Response, error) {
+	if c.hosts == nil {
+		return c.send(req)
+	}
+
+	read := req.Method == http.MethodGet
+	relative := strings.TrimPrefix(req.URL.String(), c.HostURL)
+
+	var lastErr error
+	var lastRes *http.Response
+	for _, host := range c.hosts.candidates(read) {
+		if !host.breaker.allow() {
+			continue
+		}
+
+		hostReq, err := withHost(req, host.url+relative, lastErr != nil || lastRes != nil)
+		if err != nil {
+			return nil, err
+		}
+		if lastRes != nil {
+			lastRes.Body.Close()
+			lastRes = nil
+		}
+
+		res, err := c.send(hostReq)
+		switch {
+		case err != nil:
+			host.breaker.failure()
+			if !read && !isDialError(err) {
+				return nil, err
+			}
+			lastErr = err
+		case unavailable(res.StatusCode):
+			host.breaker.failure()
+			if !read {
+				return res, nil
+			}
+			lastRes, lastErr = res, nil
+		default:
+			host.breaker.success()
+			c.hosts.stick(host)
+			trace.SpanFromContext(req.Context()).SetAttributes(attribute.String("server.address", hostReq.URL.Host))
+			return res, nil
+		}
+	}
+
+	if lastRes != nil {
+		return lastRes, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("every host is unhealthy")
+	}
+
+	return nil, fmt.Errorf("no DevOps Bootcamp API host available: %w", lastErr)
+}
+
+// withHost returns a copy of req sent to target, rewinding its body when
+// it was already sent to another host.
+func withHost(req *http.Request, target string, resend bool) (*http.Request, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+
+	hostReq := req.Clone(req.Context())
+	hostReq.URL = targetURL
+	hostReq.Host = ""
+	if resend && req.GetBody != nil {
+		hostReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hostReq, nil
+}
+
+// unavailable reports whether a status means the host cannot serve requests
+// right now, rather than that the request was wrong.
+func unavailable(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// isDialError reports whether err happened before the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
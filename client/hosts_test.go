@@ -0,0 +1,153 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// statusServer answers every request with status and counts them.
+func statusServer(t *testing.T, status int, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"id":"G63RN","name":"sloane","email":"sloane@finches.com"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestFailoverReads(t *testing.T) {
+	var primaryHits, standbyHits int32
+	primary := statusServer(t, http.StatusServiceUnavailable, &primaryHits)
+	standby := statusServer(t, http.StatusOK, &standbyHits)
+
+	c := NewClient(primary.URL)
+	c.EnableFailover([]string{primary.URL, standby.URL}, nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetEngineer("G63RN"); err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+	}
+
+	// The first request fails over, later ones stick to the standby
+	if primaryHits != 1 || standbyHits != 3 {
+		t.Errorf("primary hits = %d, standby hits = %d, want 1 and 3", primaryHits, standbyHits)
+	}
+}
+
+func TestFailoverWrites(t *testing.T) {
+	var standbyHits int32
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	standby := statusServer(t, http.StatusOK, &standbyHits)
+
+	c := NewClient(down.URL)
+	c.EnableFailover([]string{down.URL, standby.URL}, nil)
+
+	// A refused connection was never applied, so writes fail over too
+	if _, err := c.CreateEngineer(Engineer{Name: "sloane", Email: "sloane@finches.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if standbyHits != 1 {
+		t.Errorf("standby hits = %d, want 1", standbyHits)
+	}
+
+	// An unavailable response may have been applied, so it is returned
+	var primaryHits int32
+	unavailablePrimary := statusServer(t, http.StatusServiceUnavailable, &primaryHits)
+	c = NewClient(unavailablePrimary.URL)
+	c.EnableFailover([]string{unavailablePrimary.URL, standby.URL}, nil)
+	if _, err := c.CreateEngineer(Engineer{Name: "sloane", Email: "sloane@finches.com"}); err == nil {
+		t.Error("expected the unavailable write to fail")
+	}
+	if standbyHits != 1 {
+		t.Errorf("standby hits = %d, want 1", standbyHits)
+	}
+}
+
+func TestReadHosts(t *testing.T) {
+	var primaryHits, replicaHits int32
+	primary := statusServer(t, http.StatusOK, &primaryHits)
+	replica := statusServer(t, http.StatusOK, &replicaHits)
+
+	c := NewClient(primary.URL)
+	c.EnableFailover([]string{primary.URL}, []string{replica.URL})
+
+	if _, err := c.GetEngineer("G63RN"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateEngineer(Engineer{Id: "G63RN", Name: "sloane"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if primaryHits != 1 || replicaHits != 1 {
+		t.Errorf("primary hits = %d, replica hits = %d, want 1 and 1", primaryHits, replicaHits)
+	}
+}
+
+func TestFailoverEjectsUnhealthyHosts(t *testing.T) {
+	var primaryHits, standbyHits int32
+	primary := statusServer(t, http.StatusBadGateway, &primaryHits)
+	standby := statusServer(t, http.StatusServiceUnavailable, &standbyHits)
+
+	c := NewClient(primary.URL)
+	c.EnableFailover([]string{primary.URL, standby.URL}, nil)
+
+	for i := 0; i < 5; i++ {
+		_, _ = c.GetEngineer("G63RN")
+	}
+
+	// Both hosts are ejected after three failures each
+	if primaryHits != DefaultBreakerThreshold || standbyHits != DefaultBreakerThreshold {
+		t.Errorf("primary hits = %d, standby hits = %d, want %d each", primaryHits, standbyHits, DefaultBreakerThreshold)
+	}
+	for host, state := range c.HostStates() {
+		if state != BreakerOpen {
+			t.Errorf("host %s is %s, want open", host, state)
+		}
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.failure()
+	if !b.allow() {
+		t.Fatal("breaker opened before reaching the threshold")
+	}
+	b.failure()
+	if b.allow() || b.state() != BreakerOpen {
+		t.Fatalf("breaker is %s after reaching the threshold, want open", b.state())
+	}
+
+	now = now.Add(time.Minute)
+	if b.state() != BreakerHalfOpen {
+		t.Fatalf("breaker is %s after the cooldown, want half-open", b.state())
+	}
+	if !b.allow() || b.allow() {
+		t.Fatal("half-open breaker should allow exactly one trial request")
+	}
+
+	// A failed trial opens the breaker for another cooldown
+	b.failure()
+	if b.state() != BreakerOpen {
+		t.Fatalf("breaker is %s after a failed trial, want open", b.state())
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("breaker did not allow a trial after the cooldown")
+	}
+	b.success()
+	if b.state() != BreakerClosed || !b.allow() || !b.allow() {
+		t.Fatalf("breaker is %s after a successful trial, want closed", b.state())
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"sync"
+)
+
+// DefaultParallelism is the number of concurrent requests the client makes
+// for bulk operations when no parallelism is configured.
+const DefaultParallelism = 4
+
+// forEach calls fn for every index in [0, n) with at most c.Parallelism calls
+// in flight. Errors are returned indexed like the input, nil on success.
+func (c *Client) forEach(n int, fn func(i int) error) []error {
+	workers := c.Parallelism
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
+
+// AddEngsToDev - fetches engineers and adds them to dev engineers list concurrently.
+// Engineers and errors are indexed like EngIds; a failed engineer is nil with a non-nil error.
+func (c *Client) AddEngsToDev(DevId string, EngIds []string) ([]*Engineer, []error) {
+	engineers := make([]*Engineer, len(EngIds))
+
+	errs := c.forEach(len(EngIds), func(i int) error {
+		eng, err := c.GetEngineer(EngIds[i])
+		if err != nil {
+			return err
+		}
+
+		err = c.AddEngToDev(DevId, eng.Id)
+		if err != nil {
+			return err
+		}
+
+		engineers[i] = eng
+		return nil
+	})
+
+	return engineers, errs
+}
+
+// CreateEngineers - Create engineers concurrently
+// Engineers and errors are indexed like the input; a failed engineer is nil with a non-nil error.
+func (c *Client) CreateEngineers(engineers []Engineer) ([]*Engineer, []error) {
+	created := make([]*Engineer, len(engineers))
+
+	errs := c.forEach(len(engineers), func(i int) error {
+		engineer, err := c.CreateEngineer(engineers[i])
+		created[i] = engineer
+		return err
+	})
+
+	return created, errs
+}
+
+// UpdateEngineers - Update existing engineers concurrently
+// Engineers and errors are indexed like the input; a failed engineer is nil with a non-nil error.
+func (c *Client) UpdateEngineers(engineers []Engineer) ([]*Engineer, []error) {
+	updated := make([]*Engineer, len(engineers))
+
+	errs := c.forEach(len(engineers), func(i int) error {
+		engineer, err := c.UpdateEngineer(engineers[i])
+		updated[i] = engineer
+		return err
+	})
+
+	return updated, errs
+}
+
+// DeleteEngineers - Delete existing engineers concurrently
+// Errors are indexed like ids.
+func (c *Client) DeleteEngineers(ids []string) []error {
+	return c.forEach(len(ids), func(i int) error {
+		return c.DeleteEngineer(ids[i])
+	})
+}
+
+// RemoveEngsFromDev - removes engineers from dev engineers list concurrently
+// Errors are indexed like EngIds.
+func (c *Client) RemoveEngsFromDev(DevId string, EngIds []string) []error {
+	return c.forEach(len(EngIds), func(i int) error {
+		return c.RemoveEngFromDev(DevId, EngIds[i])
+	})
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachBoundsParallelism(t *testing.T) {
+	c := NewClient("")
+	c.Parallelism = 3
+
+	var inFlight, peak int32
+	errs := c.forEach(20, func(i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		if i%5 == 0 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if peak > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", peak)
+	}
+	for i, err := range errs {
+		if (i%5 == 0) != (err != nil) {
+			t.Errorf("unexpected error at index %d: %v", i, err)
+		}
+	}
+}
+
+func TestAddEngsToDev(t *testing.T) {
+	var mu sync.Mutex
+	var attached []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/engineers/id/"):
+			id := strings.TrimPrefix(r.URL.Path, "/engineers/id/")
+			if id == "missing" {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(Engineer{Id: id, Name: "name-" + id, Email: id + "@finches.com"})
+		case r.Method == http.MethodPost && r.URL.Path == "/dev/D1":
+			var payload EngineerPayload
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			mu.Lock()
+			attached = append(attached, payload.EngineerId)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(Dev{Id: "D1"})
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	c.Parallelism = 2
+
+	ids := []string{"A", "B", "missing", "C"}
+	engineers, errs := c.AddEngsToDev("D1", ids)
+
+	for i, id := range ids {
+		if id == "missing" {
+			if errs[i] == nil || engineers[i] != nil {
+				t.Errorf("expected an error for %s", id)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("unexpected error for %s: %v", id, errs[i])
+		}
+		if engineers[i].Id != id || engineers[i].Name != "name-"+id {
+			t.Errorf("engineer at index %d: got %+v", i, engineers[i])
+		}
+	}
+	if len(attached) != 3 {
+		t.Errorf("expected 3 engineers attached, got %v", attached)
+	}
+}
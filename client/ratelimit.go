@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRateLimitRetries is how many times a request rejected with 429 Too Many
+// Requests is retried once the server's quota allows it.
+const maxRateLimitRetries = 3
+
+// defaultRetryAfter is the pause applied after a 429 response without a
+// usable Retry-After header.
+const defaultRetryAfter = time.Second
+
+// DefaultMaxRateLimitWait is the longest a request waits for the server's
+// quota to reset, matching the client's request timeout.
+const DefaultMaxRateLimitWait = 10 * time.Second
+
+// ErrRateLimited is returned instead of waiting when the server asks the
+// client to pause for longer than the limiter's MaxWait.
+var ErrRateLimited = errors.New("DevOps Bootcamp API rate limit exceeded")
+
+// RateLimiter is a token bucket shared by every request a Client makes. It
+// starts at the configured rate and slows down, or pauses entirely, as the
+// server reports its quota through X-RateLimit-* headers and 429 responses.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	// MaxWait caps how long Wait pauses for the server's quota, 0 meaning
+	// no cap. Longer pauses fail with ErrRateLimited.
+	MaxWait time.Duration
+
+	mu sync.Mutex
+	// configured is the rate requested by the user, 0 meaning unlimited.
+	configured float64
+	// rate is the rate currently enforced in requests per second.
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerSecond requests on
+// average. A rate of 0 only enforces the limits reported by the server.
+func NewRateLimiter(requestsPerSecond float64) *RateLimiter {
+	burst := math.Max(1, requestsPerSecond)
+	return &RateLimiter{
+		MaxWait:    DefaultMaxRateLimitWait,
+		configured: requestsPerSecond,
+		rate:       requestsPerSecond,
+		burst:      burst,
+		tokens:     burst,
+		last:       time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or the context is done. It fails
+// with ErrRateLimited rather than block when the server paused requests for
+// longer than MaxWait. A nil limiter never blocks.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		if l.MaxWait > 0 && l.pausedUntil.Sub(now) > l.MaxWait {
+			until := l.pausedUntil
+			l.mu.Unlock()
+			return fmt.Errorf("%w, retry after %s", ErrRateLimited, until.Format(time.RFC3339))
+		}
+		delay := l.reserve(now)
+		l.mu.Unlock()
+
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and otherwise returns how long
+// to wait before trying again. Callers must hold l.mu.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Observe adjusts the limiter from the quota the server reported in res.
+func (l *RateLimiter) Observe(res *http.Response) {
+	if l == nil {
+		return
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), now)
+		if !ok {
+			wait = defaultRetryAfter
+		}
+		l.pause(now.Add(wait))
+	}
+
+	remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		l.rate = l.configured
+		return
+	}
+	reset, ok := parseRateLimitReset(res.Header.Get("X-RateLimit-Reset"), now)
+	if !ok || !reset.After(now) {
+		l.rate = l.configured
+		return
+	}
+
+	if remaining <= 0 {
+		l.pause(reset)
+		return
+	}
+
+	// Spread the remaining quota over the rest of the window
+	serverRate := float64(remaining) / reset.Sub(now).Seconds()
+	if l.configured <= 0 || serverRate < l.configured {
+		l.rate = serverRate
+	} else {
+		l.rate = l.configured
+	}
+}
+
+// pause stops all requests until the given time. Callers must hold l.mu.
+func (l *RateLimiter) pause(until time.Time) {
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.tokens = 0
+	l.last = l.pausedUntil
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
+
+// parseRateLimitReset reads an X-RateLimit-Reset header given either as a
+// Unix timestamp or as a number of seconds until the window resets.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+	// Values this large can only be timestamps
+	if reset > 1_000_000_000 {
+		return time.Unix(reset, 0), true
+	}
+
+	return now.Add(time.Duration(reset) * time.Second), true
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterPacesRequests(t *testing.T) {
+	l := NewRateLimiter(50)
+	l.tokens = 0
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 10 requests at 50/s need at least 200ms once the bucket is empty
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("expected requests to be paced, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitHonorsContext(t *testing.T) {
+	l := NewRateLimiter(0)
+	l.pause(time.Now().Add(5 * time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected Wait to fail once the context is done")
+	}
+}
+
+func TestRateLimiterWaitRefusesLongPause(t *testing.T) {
+	l := NewRateLimiter(0)
+
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	res.Header.Set("Retry-After", "3600")
+	l.Observe(res)
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Wait to fail without pausing, took %s", elapsed)
+	}
+
+	l.MaxWait = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected an uncapped limiter to wait for the pause, got %v", err)
+	}
+}
+
+func TestRateLimiterObserveQuotaHeaders(t *testing.T) {
+	l := NewRateLimiter(100)
+
+	res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	res.Header.Set("X-RateLimit-Remaining", "10")
+	res.Header.Set("X-RateLimit-Reset", "5")
+	l.Observe(res)
+	if l.rate > 2.1 || l.rate < 1.9 {
+		t.Errorf("expected rate to drop to about 2/s, got %f", l.rate)
+	}
+
+	res.Header.Set("X-RateLimit-Remaining", "0")
+	l.Observe(res)
+	if !l.pausedUntil.After(time.Now().Add(4 * time.Second)) {
+		t.Errorf("expected limiter to pause until the quota resets, paused until %s", l.pausedUntil)
+	}
+}
+
+func TestDoRequestRetriesTooManyRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", strconv.Itoa(1))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	start := time.Now()
+	if _, err := c.GetEngineers(); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected the request to be retried once, got %d calls", calls)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the retry to wait for Retry-After, took %s", elapsed)
+	}
+}
+
+func TestDoRequestFailsOnLongRateLimitReset(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	c.SetCircuitBreaker(0, 0)
+	start := time.Now()
+	if _, err := c.GetEngineers(); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the request not to be retried, got %d calls", calls)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the request to fail without waiting for the reset, took %s", elapsed)
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans of API requests. Without a configured
+// OpenTelemetry tracer provider the spans are no-ops.
+const tracerName = "github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+
+// startSpan starts a client span for an API request, with the entity type
+// and ID taken from its path.
+func startSpan(req *http.Request) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.URL.Path),
+		attribute.String("server.address", req.URL.Host),
+	}
+	entityType, entityID := entityFromPath(req.URL.Path)
+	if entityType != "" {
+		attributes = append(attributes, attribute.String("devops_bootcamp.entity.type", entityType))
+	}
+	if entityID != "" {
+		attributes = append(attributes, attribute.String("devops_bootcamp.entity.id", entityID))
+	}
+
+	return otel.Tracer(tracerName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// endSpan records the outcome of a request and ends its span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// entityFromPath returns the entity type and ID an API path refers to, such
+// as ("engineer", "G63RN") for /engineers/id/G63RN.
+func entityFromPath(path string) (entityType, entityID string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch parts[0] {
+	case "engineers":
+		entityType = "engineer"
+	case "dev":
+		entityType = "dev"
+	default:
+		return "", ""
+	}
+
+	if len(parts) > 1 && parts[1] == "id" {
+		parts = parts[1:]
+	}
+	if len(parts) > 1 {
+		entityID = parts[1]
+	}
+
+	return entityType, entityID
+}
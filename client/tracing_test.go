@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			http.Error(w, "engineer not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"G63RN","name":"sloane","email":"sloane@finches.com"}`))
+	}))
+	defer server.Close()
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+	c := NewClient(server.URL).WithContext(ctx)
+	if _, err := c.GetEngineer("G63RN"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteEngineer("MISSING"); err == nil {
+		t.Fatal("expected an error deleting a missing engineer")
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	get, del := spans[0], spans[1]
+	if get.Name != "HTTP GET" || del.Name != "HTTP DELETE" {
+		t.Errorf("unexpected span names %q and %q", get.Name, del.Name)
+	}
+	for _, span := range []tracetest.SpanStub{get, del} {
+		if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the request context", span.Name)
+		}
+	}
+
+	wantGet := map[attribute.Key]attribute.Value{
+		"devops_bootcamp.entity.type": attribute.StringValue("engineer"),
+		"devops_bootcamp.entity.id":   attribute.StringValue("G63RN"),
+		"http.response.status_code":   attribute.IntValue(http.StatusOK),
+	}
+	for key, want := range wantGet {
+		if got := spanAttribute(get, key); got != want {
+			t.Errorf("GET span %s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	if got := spanAttribute(del, "http.response.status_code"); got != attribute.IntValue(http.StatusNotFound) {
+		t.Errorf("DELETE span status code = %v, want 404", got.Emit())
+	}
+	if del.Status.Code != codes.Error {
+		t.Errorf("DELETE span status = %v, want error", del.Status.Code)
+	}
+}
+
+func TestEntityFromPath(t *testing.T) {
+	tests := []struct {
+		path, entityType, entityID string
+	}{
+		{"/engineers", "engineer", ""},
+		{"/engineers/id/G63RN", "engineer", "G63RN"},
+		{"/engineers/G63RN", "engineer", "G63RN"},
+		{"/dev/id/D1", "dev", "D1"},
+		{"/dev/D1/G63RN", "dev", "D1"},
+		{"/version", "", ""},
+	}
+
+	for _, test := range tests {
+		entityType, entityID := entityFromPath(test.path)
+		if entityType != test.entityType || entityID != test.entityID {
+			t.Errorf("entityFromPath(%q) = %q, %q, want %q, %q", test.path, entityType, entityID, test.entityType, test.entityID)
+		}
+	}
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+
+	return attribute.Value{}
+}
@@ -0,0 +1,104 @@
+package client
+
+import "encoding/json"
+
+// Engineer is an engineer as served by the DevOps Bootcamp API.
+type Engineer struct {
+	Name  string `json:"name"`
+	Id    string `json:"id"`
+	Email string `json:"email"`
+	// Active is false for archived engineers. Servers that do not track
+	// archiving omit it.
+	Active *bool `json:"active,omitempty"`
+	// Role is one of RoleDev, RoleOps or RoleSRE, or empty when unassigned.
+	Role string `json:"role,omitempty"`
+	// Level is the engineer's seniority, such as "junior" or "senior".
+	Level  string            `json:"level,omitempty"`
+	Skills []string          `json:"skills,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// UnmarshalJSON decodes an engineer, leaving empty skills and labels nil as
+// encoding omits them.
+func (e *Engineer) UnmarshalJSON(data []byte) error {
+	type plainEngineer Engineer
+	var engineer plainEngineer
+	if err := json.Unmarshal(data, &engineer); err != nil {
+		return err
+	}
+
+	if len(engineer.Skills) == 0 {
+		engineer.Skills = nil
+	}
+	if len(engineer.Labels) == 0 {
+		engineer.Labels = nil
+	}
+
+	*e = Engineer(engineer)
+	return nil
+}
+
+// Roles an engineer can be assigned.
+const (
+	RoleDev = "dev"
+	RoleOps = "ops"
+	RoleSRE = "sre"
+)
+
+// Roles lists every role an engineer can be assigned.
+var Roles = []string{RoleDev, RoleOps, RoleSRE}
+
+// IsActive reports whether the engineer is active. Engineers from servers
+// that do not track archiving are always active.
+func (e Engineer) IsActive() bool {
+	return e.Active == nil || *e.Active
+}
+
+// Dev is a dev team as served by the DevOps Bootcamp API, listing the
+// engineers that are its members.
+type Dev struct {
+	Name        string            `json:"name"`
+	Id          string            `json:"id"`
+	Engineers   []*Engineer       `json:"engineers"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// OwnerEngineerId is the ID of the engineer owning the dev, who must be
+	// one of its engineers.
+	OwnerEngineerId string `json:"owner_engineer_id,omitempty"`
+}
+
+// HasLabels reports whether the dev carries every label in labels.
+func (d Dev) HasLabels(labels map[string]string) bool {
+	for key, value := range labels {
+		if current, ok := d.Labels[key]; !ok || current != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnmarshalJSON decodes a dev, dropping null engineers so every entry in
+// Engineers can be dereferenced, and leaving empty labels nil as encoding
+// omits them.
+func (d *Dev) UnmarshalJSON(data []byte) error {
+	type plainDev Dev
+	var dev plainDev
+	if err := json.Unmarshal(data, &dev); err != nil {
+		return err
+	}
+
+	engineers := dev.Engineers[:0]
+	for _, engineer := range dev.Engineers {
+		if engineer != nil {
+			engineers = append(engineers, engineer)
+		}
+	}
+	dev.Engineers = engineers
+	if len(dev.Labels) == 0 {
+		dev.Labels = nil
+	}
+
+	*d = Dev(dev)
+	return nil
+}
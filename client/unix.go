@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Host URL schemes served over a Unix domain socket. unix:///path/to/sock
+// names the socket by its path, http+unix://%2Fpath%2Fto%2Fsock/prefix
+// names it by its escaped path and may add a base path.
+const (
+	unixScheme     = "unix"
+	httpUnixScheme = "http+unix"
+)
+
+// socketDialer dials Unix sockets for the pseudo hosts socket URLs are
+// rewritten to, and TCP for every other host.
+type socketDialer struct {
+	net.Dialer
+
+	mu      sync.Mutex
+	sockets map[string]string
+}
+
+func newSocketDialer() *socketDialer {
+	return &socketDialer{sockets: map[string]string{}}
+}
+
+// DialContext implements http.Transport.DialContext.
+func (d *socketDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err == nil {
+		d.mu.Lock()
+		socket, ok := d.sockets[host]
+		d.mu.Unlock()
+		if ok {
+			return d.Dialer.DialContext(ctx, "unix", socket)
+		}
+	}
+
+	return d.Dialer.DialContext(ctx, network, address)
+}
+
+// ValidateHost checks that a Unix socket host URL names a socket. Other
+// URLs are left to the HTTP client.
+func ValidateHost(host string) error {
+	_, _, err := parseSocketHost(host)
+	return err
+}
+
+// parseSocketHost returns the socket and base path of a socket host URL,
+// and an empty socket for other URLs. The escaped socket of http+unix URLs
+// is not a valid URL host, so they are split by hand.
+func parseSocketHost(host string) (socket, basePath string, err error) {
+	scheme, rest, found := strings.Cut(host, "://")
+	if !found {
+		return "", "", nil
+	}
+
+	switch scheme {
+	case unixScheme:
+		socket = rest
+	case httpUnixScheme:
+		escaped, path, _ := strings.Cut(rest, "/")
+		socket, err = url.PathUnescape(escaped)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid socket in host %q: %w", host, err)
+		}
+		if path = strings.TrimSuffix(path, "/"); path != "" {
+			basePath = "/" + path
+		}
+	default:
+		return "", "", nil
+	}
+	if socket == "" {
+		return "", "", fmt.Errorf("host %q does not name a socket", host)
+	}
+
+	return socket, basePath, nil
+}
+
+// resolve rewrites a socket host URL to plain HTTP on a pseudo host that
+// dials the socket. Other URLs, and invalid socket URLs, are returned
+// unchanged.
+func (d *socketDialer) resolve(host string) string {
+	socket, basePath, err := parseSocketHost(host)
+	if err != nil || socket == "" {
+		return host
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	pseudoHost := ""
+	for name, path := range d.sockets {
+		if path == socket {
+			pseudoHost = name
+		}
+	}
+	if pseudoHost == "" {
+		pseudoHost = fmt.Sprintf("unix-socket-%d", len(d.sockets)+1)
+		d.sockets[pseudoHost] = socket
+	}
+
+	return "http://" + pseudoHost + basePath
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+// socketServer serves handler on a Unix socket in a temporary directory and
+// returns the socket path.
+func socketServer(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return socket
+}
+
+func TestUnixSocketHosts(t *testing.T) {
+	var paths []string
+	socket := socketServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"G63RN","name":"sloane","email":"sloane@finches.com"}`))
+	}))
+
+	hosts := []string{
+		"unix://" + socket,
+		"http+unix://" + url.PathEscape(socket) + "/api/",
+	}
+	for _, host := range hosts {
+		engineer, err := NewClient(host).GetEngineer("G63RN")
+		if err != nil {
+			t.Fatalf("%s: %s", host, err)
+		}
+		if engineer.Name != "sloane" {
+			t.Errorf("%s: unexpected engineer %+v", host, engineer)
+		}
+	}
+
+	want := []string{"/engineers/id/G63RN", "/api/engineers/id/G63RN"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("server saw paths %v, want %v", paths, want)
+	}
+}
+
+func TestUnixSocketFailover(t *testing.T) {
+	socket := socketServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	missing := filepath.Join(t.TempDir(), "missing.sock")
+
+	c := NewClient("unix://" + missing)
+	c.EnableFailover([]string{"unix://" + missing, "unix://" + socket}, nil)
+	if _, err := c.GetEngineers(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateHost(t *testing.T) {
+	tests := map[string]bool{
+		"http://localhost:8080":         true,
+		"unix:///var/run/api.sock":      true,
+		"http+unix://%2Fvar%2Fapi.sock": true,
+		"unix://":                       false,
+		"http+unix://%zz":               false,
+	}
+
+	for host, valid := range tests {
+		if err := ValidateHost(host); (err == nil) != valid {
+			t.Errorf("ValidateHost(%q) = %v, want valid %t", host, err, valid)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Optional API features. Servers list the ones they support in /version.
+const (
+	// FeatureArchive marks engineers inactive through their active field.
+	FeatureArchive = "archive"
+	// FeatureRemoveEngineer removes an engineer from a dev with
+	// DELETE /dev/{id}/{engineer id}.
+	FeatureRemoveEngineer = "remove-engineer"
+)
+
+// featureVersions are the API versions that introduced each feature, used
+// for servers that report a version without a feature list.
+var featureVersions = map[string]*version.Version{
+	FeatureRemoveEngineer: version.Must(version.NewVersion("1.1.0")),
+	FeatureArchive:        version.Must(version.NewVersion("1.2.0")),
+}
+
+// ServerInfo describes the API served at the client host.
+type ServerInfo struct {
+	// Version is empty for servers without a /version endpoint.
+	Version  string   `json:"version"`
+	Features []string `json:"features,omitempty"`
+}
+
+// GetServerInfo - Returns the API version and features
+func (c *Client) GetServerInfo() (*ServerInfo, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/version", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	info := ServerInfo{}
+	err = json.Unmarshal(body, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// Verify checks that the API is reachable and returns its version, to be
+// recorded in Client.Server. Servers that predate /version are checked by
+// listing engineers and reported with an empty version.
+func (c *Client) Verify() (*ServerInfo, error) {
+	info, err := c.GetServerInfo()
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		if _, err := c.GetEngineers(); err != nil {
+			return nil, err
+		}
+		return &ServerInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// Supports reports whether the server supports an optional feature. Servers
+// that were not verified, or do not report a version, are assumed to
+// support everything so behavior stays the same as without verification.
+func (c *Client) Supports(feature string) bool {
+	if c.Server == nil || c.Server.Version == "" {
+		return true
+	}
+
+	if c.Server.Features != nil {
+		for _, supported := range c.Server.Features {
+			if supported == feature {
+				return true
+			}
+		}
+		return false
+	}
+
+	introduced, ok := featureVersions[feature]
+	if !ok {
+		return false
+	}
+	current, err := version.NewVersion(c.Server.Version)
+	if err != nil {
+		return false
+	}
+
+	return current.GreaterThanOrEqual(introduced)
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	tests := map[string]struct {
+		handler http.HandlerFunc
+		want    *ServerInfo
+		wantErr bool
+	}{
+		"version endpoint": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version":"1.2.0","features":["archive"]}`))
+			},
+			want: &ServerInfo{Version: "1.2.0", Features: []string{"archive"}},
+		},
+		"without version endpoint": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/version" {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(`[]`))
+			},
+			want: &ServerInfo{},
+		},
+		"unhealthy": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(test.handler)
+			defer server.Close()
+
+			got, err := NewClient(server.URL).Verify()
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Verify() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSupports(t *testing.T) {
+	tests := []struct {
+		server  *ServerInfo
+		feature string
+		want    bool
+	}{
+		{server: nil, feature: FeatureArchive, want: true},
+		{server: &ServerInfo{}, feature: FeatureArchive, want: true},
+		{server: &ServerInfo{Version: "1.2.0", Features: []string{FeatureRemoveEngineer}}, feature: FeatureRemoveEngineer, want: true},
+		{server: &ServerInfo{Version: "1.2.0", Features: []string{FeatureRemoveEngineer}}, feature: FeatureArchive, want: false},
+		{server: &ServerInfo{Version: "1.1.0"}, feature: FeatureRemoveEngineer, want: true},
+		{server: &ServerInfo{Version: "1.1.0"}, feature: FeatureArchive, want: false},
+		{server: &ServerInfo{Version: "v1.3.0-beta"}, feature: FeatureArchive, want: true},
+		{server: &ServerInfo{Version: "1.0.0"}, feature: "unknown", want: false},
+	}
+
+	for _, test := range tests {
+		c := &Client{Server: test.server}
+		if got := c.Supports(test.feature); got != test.want {
+			t.Errorf("Supports(%q) with %+v = %t, want %t", test.feature, test.server, got, test.want)
+		}
+	}
+}
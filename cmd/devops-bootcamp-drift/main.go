@@ -0,0 +1,319 @@
+// Command devops-bootcamp-drift compares a Terraform state with a DevOps
+// Bootcamp API and reports engineers and devs that were changed, deleted or
+// created outside of Terraform. Unlike terraform plan it also lists objects
+// no configuration manages, which is what classroom cleanup audits need.
+//
+// Usage:
+//
+//	terraform show -json > state.json
+//	devops-bootcamp-drift -host http://localhost:8080 -state state.json [-format json] [-detailed-exitcode]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+)
+
+// Resource types managed by the provider.
+const (
+	engineerResourceType = "devops-bootcamp_engineer_resource"
+	devResourceType      = "devops-bootcamp_dev_resource"
+	rosterResourceType   = "devops-bootcamp_engineer_roster"
+)
+
+func main() {
+	host := flag.String("host", os.Getenv("HOST"), "DevOps Bootcamp API endpoint, defaults to the HOST environment variable")
+	statePath := flag.String("state", "-", "output of terraform show -json, - reads it from stdin")
+	format := flag.String("format", "text", "report format, text or json")
+	detailedExitCode := flag.Bool("detailed-exitcode", false, "exit with status 2 when drift is found")
+	flag.Parse()
+
+	if *host == "" {
+		log.Fatal("missing API endpoint: set -host or the HOST environment variable")
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("unknown format %q, expected text or json", *format)
+	}
+
+	input := os.Stdin
+	if *statePath != "-" {
+		file, err := os.Open(*statePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	var state tfjson.State
+	if err := json.NewDecoder(input).Decode(&state); err != nil {
+		log.Fatalf("reading state: %s", err)
+	}
+
+	result, err := detect(client.NewClient(*host), &state)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *format == "json" {
+		err = json.NewEncoder(os.Stdout).Encode(result)
+	} else {
+		err = result.write(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *detailedExitCode && result.hasDrift() {
+		os.Exit(2)
+	}
+}
+
+// report lists the objects that drifted from the Terraform state.
+type report struct {
+	Changed   []finding `json:"changed"`
+	Deleted   []finding `json:"deleted"`
+	Unmanaged []finding `json:"unmanaged"`
+}
+
+// finding describes a single drifted object.
+type finding struct {
+	Kind    string   `json:"kind"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Address string   `json:"address,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// managedEngineer and managedDev are the objects recorded in the state.
+type managedEngineer struct {
+	address, name, email string
+}
+
+type managedDev struct {
+	address, name string
+	engineers     []string
+}
+
+// detect compares the managed objects in state with the live API.
+func detect(c *client.Client, state *tfjson.State) (*report, error) {
+	engineers := map[string]managedEngineer{}
+	devs := map[string]managedDev{}
+	if state.Values != nil {
+		collect(state.Values.RootModule, engineers, devs)
+	}
+
+	liveEngineers, err := c.GetEngineers()
+	if err != nil {
+		return nil, fmt.Errorf("reading engineers: %w", err)
+	}
+	liveDevs, err := c.GetDevs()
+	if err != nil {
+		return nil, fmt.Errorf("reading devs: %w", err)
+	}
+
+	result := &report{Changed: []finding{}, Deleted: []finding{}, Unmanaged: []finding{}}
+
+	seen := map[string]bool{}
+	for _, live := range liveEngineers {
+		seen[live.Id] = true
+		managed, ok := engineers[live.Id]
+		if !ok {
+			result.Unmanaged = append(result.Unmanaged, finding{Kind: "engineer", ID: live.Id, Name: live.Name})
+			continue
+		}
+
+		var changes []string
+		changes = appendChange(changes, "name", managed.name, live.Name)
+		changes = appendChange(changes, "email", managed.email, live.Email)
+		if len(changes) > 0 {
+			result.Changed = append(result.Changed, finding{Kind: "engineer", ID: live.Id, Name: live.Name, Address: managed.address, Changes: changes})
+		}
+	}
+	for _, id := range sortedKeys(engineers) {
+		if !seen[id] {
+			result.Deleted = append(result.Deleted, finding{Kind: "engineer", ID: id, Name: engineers[id].name, Address: engineers[id].address})
+		}
+	}
+
+	seen = map[string]bool{}
+	for _, live := range liveDevs {
+		seen[live.Id] = true
+		managed, ok := devs[live.Id]
+		if !ok {
+			result.Unmanaged = append(result.Unmanaged, finding{Kind: "dev", ID: live.Id, Name: live.Name})
+			continue
+		}
+
+		liveEngineerIDs := make([]string, 0, len(live.Engineers))
+		for _, engineer := range live.Engineers {
+			liveEngineerIDs = append(liveEngineerIDs, engineer.Id)
+		}
+
+		var changes []string
+		changes = appendChange(changes, "name", managed.name, live.Name)
+		changes = append(changes, membershipChanges(managed.engineers, liveEngineerIDs)...)
+		if len(changes) > 0 {
+			result.Changed = append(result.Changed, finding{Kind: "dev", ID: live.Id, Name: live.Name, Address: managed.address, Changes: changes})
+		}
+	}
+	for _, id := range sortedKeys(devs) {
+		if !seen[id] {
+			result.Deleted = append(result.Deleted, finding{Kind: "dev", ID: id, Name: devs[id].name, Address: devs[id].address})
+		}
+	}
+
+	return result, nil
+}
+
+// collect records the engineers and devs managed in module and its children.
+func collect(module *tfjson.StateModule, engineers map[string]managedEngineer, devs map[string]managedDev) {
+	if module == nil {
+		return
+	}
+
+	for _, resource := range module.Resources {
+		if resource.Mode != tfjson.ManagedResourceMode {
+			continue
+		}
+		values := resource.AttributeValues
+
+		switch resource.Type {
+		case engineerResourceType:
+			engineers[stringValue(values, "id")] = managedEngineer{
+				address: resource.Address,
+				name:    stringValue(values, "name"),
+				email:   stringValue(values, "email"),
+			}
+		case rosterResourceType:
+			members, _ := values["members"].(map[string]interface{})
+			for key, member := range members {
+				member, _ := member.(map[string]interface{})
+				engineers[stringValue(member, "id")] = managedEngineer{
+					address: fmt.Sprintf("%s.members[%q]", resource.Address, key),
+					name:    stringValue(member, "name"),
+					email:   stringValue(member, "email"),
+				}
+			}
+		case devResourceType:
+			dev := managedDev{
+				address: resource.Address,
+				name:    stringValue(values, "name"),
+			}
+			members, _ := values["engineers"].([]interface{})
+			for _, member := range members {
+				member, _ := member.(map[string]interface{})
+				dev.engineers = append(dev.engineers, stringValue(member, "id"))
+			}
+			devs[stringValue(values, "id")] = dev
+		}
+	}
+
+	for _, child := range module.ChildModules {
+		collect(child, engineers, devs)
+	}
+}
+
+// membershipChanges describes the engineers attached to or detached from a
+// dev outside of Terraform.
+func membershipChanges(managed, live []string) []string {
+	inManaged := map[string]bool{}
+	for _, id := range managed {
+		inManaged[id] = true
+	}
+	inLive := map[string]bool{}
+	for _, id := range live {
+		inLive[id] = true
+	}
+
+	var changes []string
+	for _, id := range live {
+		if !inManaged[id] {
+			changes = append(changes, "engineer "+id+" attached")
+		}
+	}
+	for _, id := range managed {
+		if !inLive[id] {
+			changes = append(changes, "engineer "+id+" detached")
+		}
+	}
+
+	return changes
+}
+
+func appendChange(changes []string, attribute, managed, live string) []string {
+	if managed == live {
+		return changes
+	}
+
+	return append(changes, fmt.Sprintf("%s: %q -> %q", attribute, managed, live))
+}
+
+func stringValue(values map[string]interface{}, key string) string {
+	value, _ := values[key].(string)
+	return value
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// hasDrift reports whether anything differs from the state.
+func (r *report) hasDrift() bool {
+	return len(r.Changed)+len(r.Deleted)+len(r.Unmanaged) > 0
+}
+
+// write prints the report for humans.
+func (r *report) write(w io.Writer) error {
+	if !r.hasDrift() {
+		_, err := fmt.Fprintln(w, "No drift: the API matches the Terraform state.")
+		return err
+	}
+
+	sections := []struct {
+		title    string
+		findings []finding
+	}{
+		{"Changed outside of Terraform", r.Changed},
+		{"Deleted outside of Terraform", r.Deleted},
+		{"Not managed by Terraform", r.Unmanaged},
+	}
+	for _, section := range sections {
+		if len(section.findings) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s:\n", section.title); err != nil {
+			return err
+		}
+		for _, f := range section.findings {
+			line := fmt.Sprintf("  %s %s %q", f.Kind, f.ID, f.Name)
+			if f.Address != "" {
+				line += " (" + f.Address + ")"
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+			for _, change := range f.Changes {
+				if _, err := fmt.Fprintf(w, "      %s\n", change); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
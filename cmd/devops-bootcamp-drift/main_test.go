@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+const testState = `{
+  "format_version": "1.0",
+  "terraform_version": "1.8.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "devops-bootcamp_engineer_resource.sloane",
+          "mode": "managed",
+          "type": "devops-bootcamp_engineer_resource",
+          "name": "sloane",
+          "values": {"id": "G63RN", "name": "sloane", "email": "sloane@finches.com"}
+        },
+        {
+          "address": "devops-bootcamp_engineer_resource.gone",
+          "mode": "managed",
+          "type": "devops-bootcamp_engineer_resource",
+          "name": "gone",
+          "values": {"id": "GONE1", "name": "gone", "email": "gone@finches.com"}
+        },
+        {
+          "address": "data.devops-bootcamp_engineer.all",
+          "mode": "data",
+          "type": "devops-bootcamp_engineer",
+          "name": "all",
+          "values": {}
+        }
+      ],
+      "child_modules": [
+        {
+          "address": "module.team",
+          "resources": [
+            {
+              "address": "module.team.devops-bootcamp_dev_resource.finches",
+              "mode": "managed",
+              "type": "devops-bootcamp_dev_resource",
+              "name": "finches",
+              "values": {"id": "D1", "name": "dev_finches", "engineers": [{"id": "G63RN"}, {"id": "R0001"}]}
+            },
+            {
+              "address": "module.team.devops-bootcamp_engineer_roster.cohort",
+              "mode": "managed",
+              "type": "devops-bootcamp_engineer_roster",
+              "name": "cohort",
+              "values": {"id": "roster-1", "members": {"ryan@finches.com": {"id": "R0001", "name": "ryan", "email": "ryan@finches.com", "status": "created"}}}
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`
+
+func TestDetect(t *testing.T) {
+	api := fakeserver.New()
+	api.AddEngineer(client.Engineer{Id: "G63RN", Name: "sloane.renamed", Email: "sloane@finches.com"})
+	api.AddEngineer(client.Engineer{Id: "R0001", Name: "ryan", Email: "ryan@finches.com"})
+	api.AddEngineer(client.Engineer{Id: "STRAY", Name: "stray", Email: "stray@finches.com"})
+	api.AddDev(client.Dev{Id: "D1", Name: "dev_finches"}, "G63RN", "STRAY")
+	api.AddDev(client.Dev{Id: "D2", Name: "leftover"})
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	var state tfjson.State
+	if err := json.Unmarshal([]byte(testState), &state); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := detect(client.NewClient(server.URL), &state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &report{
+		Changed: []finding{
+			{Kind: "engineer", ID: "G63RN", Name: "sloane.renamed", Address: "devops-bootcamp_engineer_resource.sloane", Changes: []string{`name: "sloane" -> "sloane.renamed"`}},
+			{Kind: "dev", ID: "D1", Name: "dev_finches", Address: "module.team.devops-bootcamp_dev_resource.finches", Changes: []string{"engineer STRAY attached", "engineer R0001 detached"}},
+		},
+		Deleted: []finding{
+			{Kind: "engineer", ID: "GONE1", Name: "gone", Address: "devops-bootcamp_engineer_resource.gone"},
+		},
+		Unmanaged: []finding{
+			{Kind: "engineer", ID: "STRAY", Name: "stray"},
+			{Kind: "dev", ID: "D2", Name: "leftover"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected report:\n%+v\nwant:\n%+v", got, want)
+	}
+
+	var out bytes.Buffer
+	if err := got.write(&out); err != nil {
+		t.Fatal(err)
+	}
+	wantText := `Changed outside of Terraform:
+  engineer G63RN "sloane.renamed" (devops-bootcamp_engineer_resource.sloane)
+      name: "sloane" -> "sloane.renamed"
+  dev D1 "dev_finches" (module.team.devops-bootcamp_dev_resource.finches)
+      engineer STRAY attached
+      engineer R0001 detached
+Deleted outside of Terraform:
+  engineer GONE1 "gone" (devops-bootcamp_engineer_resource.gone)
+Not managed by Terraform:
+  engineer STRAY "stray"
+  dev D2 "leftover"
+`
+	if out.String() != wantText {
+		t.Errorf("unexpected text report:\n%s\nwant:\n%s", out.String(), wantText)
+	}
+}
+
+func TestDetectNoDrift(t *testing.T) {
+	server := httptest.NewServer(fakeserver.New())
+	defer server.Close()
+
+	got, err := detect(client.NewClient(server.URL), &tfjson.State{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.hasDrift() {
+		t.Errorf("expected no drift, got %+v", got)
+	}
+}
@@ -0,0 +1,229 @@
+// Command devops-bootcamp-export reads every engineer and dev from a DevOps
+// Bootcamp API and writes Terraform configuration with matching import
+// blocks, so an existing environment can be adopted with a single apply.
+//
+// Usage:
+//
+//	devops-bootcamp-export -host http://localhost:8080 -out ./adopted
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	engineerResourceType = "devops-bootcamp_engineer_resource"
+	devResourceType      = "devops-bootcamp_dev_resource"
+)
+
+func main() {
+	host := flag.String("host", os.Getenv("HOST"), "DevOps Bootcamp API endpoint, defaults to the HOST environment variable")
+	out := flag.String("out", ".", "directory the generated .tf files are written to")
+	flag.Parse()
+
+	if *host == "" {
+		log.Fatal("missing API endpoint: set -host or the HOST environment variable")
+	}
+
+	files, err := export(client.NewClient(*host))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(*out, name)
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("wrote", path)
+	}
+}
+
+// export renders the engineers and devs on the API as the contents of
+// engineers.tf, devs.tf and imports.tf.
+func export(c *client.Client) (map[string][]byte, error) {
+	engineers, err := c.GetEngineers()
+	if err != nil {
+		return nil, fmt.Errorf("reading engineers: %w", err)
+	}
+	devs, err := c.GetDevs()
+	if err != nil {
+		return nil, fmt.Errorf("reading devs: %w", err)
+	}
+
+	engineersFile := hclwrite.NewEmptyFile()
+	devsFile := hclwrite.NewEmptyFile()
+	importsFile := hclwrite.NewEmptyFile()
+	names := resourceNames{}
+
+	// engineerNames maps engineer IDs to their generated resource names
+	engineerNames := make(map[string]string, len(engineers))
+	for _, engineer := range engineers {
+		name := names.next(engineerResourceType, engineer.Name, "engineer")
+		engineerNames[engineer.Id] = name
+
+		body := appendBlock(engineersFile, "resource", engineerResourceType, name)
+		body.SetAttributeValue("name", cty.StringVal(engineer.Name))
+		body.SetAttributeValue("email", cty.StringVal(engineer.Email))
+		if engineer.Role != "" {
+			body.SetAttributeValue("role", cty.StringVal(engineer.Role))
+		}
+		if engineer.Level != "" {
+			body.SetAttributeValue("level", cty.StringVal(engineer.Level))
+		}
+		if len(engineer.Skills) > 0 {
+			skills := make([]cty.Value, len(engineer.Skills))
+			for index, skill := range engineer.Skills {
+				skills[index] = cty.StringVal(skill)
+			}
+			body.SetAttributeValue("skills", cty.SetVal(skills))
+		}
+
+		appendImport(importsFile, engineerResourceType, name, engineer.Id)
+	}
+
+	for _, dev := range devs {
+		name := names.next(devResourceType, dev.Name, "dev")
+
+		body := appendBlock(devsFile, "resource", devResourceType, name)
+		body.SetAttributeValue("name", cty.StringVal(dev.Name))
+		if dev.Description != "" {
+			body.SetAttributeValue("description", cty.StringVal(dev.Description))
+		}
+		if len(dev.Labels) > 0 {
+			labels := make(map[string]cty.Value, len(dev.Labels))
+			for key, value := range dev.Labels {
+				labels[key] = cty.StringVal(value)
+			}
+			body.SetAttributeValue("labels", cty.MapVal(labels))
+		}
+		if dev.OwnerEngineerId != "" {
+			body.SetAttributeRaw("owner_engineer_id", engineerID(engineerNames, dev.OwnerEngineerId))
+		}
+		if len(dev.Engineers) > 0 {
+			IDs := make([]hclwrite.Tokens, 0, len(dev.Engineers))
+			for _, engineer := range dev.Engineers {
+				IDs = append(IDs, engineerID(engineerNames, engineer.Id))
+			}
+			body.SetAttributeRaw("engineers", idObjects(IDs))
+		}
+
+		appendImport(importsFile, devResourceType, name, dev.Id)
+	}
+
+	return map[string][]byte{
+		"engineers.tf": hclwrite.Format(engineersFile.Bytes()),
+		"devs.tf":      hclwrite.Format(devsFile.Bytes()),
+		"imports.tf":   hclwrite.Format(importsFile.Bytes()),
+	}, nil
+}
+
+// appendBlock appends a labelled block, separated from the previous one by
+// a blank line, and returns its body.
+func appendBlock(file *hclwrite.File, blockType string, labels ...string) *hclwrite.Body {
+	if len(file.Body().Blocks()) > 0 {
+		file.Body().AppendNewline()
+	}
+
+	return file.Body().AppendNewBlock(blockType, labels).Body()
+}
+
+// appendImport appends an import block adopting id into the named resource.
+func appendImport(file *hclwrite.File, resourceType, name, id string) {
+	body := appendBlock(file, "import")
+	body.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	body.SetAttributeValue("id", cty.StringVal(id))
+}
+
+// engineerID references the exported engineer resource with the given ID,
+// falling back to the literal ID for engineers that were not exported.
+func engineerID(engineerNames map[string]string, id string) hclwrite.Tokens {
+	name, ok := engineerNames[id]
+	if !ok {
+		return hclwrite.TokensForValue(cty.StringVal(id))
+	}
+
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: engineerResourceType},
+		hcl.TraverseAttr{Name: name},
+		hcl.TraverseAttr{Name: "id"},
+	})
+}
+
+// idObjects renders a list of { id = ... } objects, one per line, the way
+// dev engineers are written by hand.
+func idObjects(IDs []hclwrite.Tokens) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")},
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+	}
+	for _, ID := range IDs {
+		tokens = append(tokens,
+			&hclwrite.Token{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")},
+			&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte("id")},
+			&hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")},
+		)
+		tokens = append(tokens, ID...)
+		tokens = append(tokens,
+			&hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")},
+			&hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")},
+			&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		)
+	}
+
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+}
+
+// resourceNames hands out unique resource names per resource type.
+type resourceNames map[string]bool
+
+// next derives a resource name from an object name, lower-casing it and
+// replacing every run of other characters with an underscore. Names that
+// are empty or start with a digit are prefixed, and repeated names are
+// numbered.
+func (n resourceNames) next(resourceType, objectName, prefix string) string {
+	var name strings.Builder
+	pending := false
+	for _, r := range strings.ToLower(objectName) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pending && name.Len() > 0 {
+				name.WriteByte('_')
+			}
+			name.WriteRune(r)
+			pending = false
+			continue
+		}
+		pending = true
+	}
+
+	base := name.String()
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = strings.TrimSuffix(prefix+"_"+base, "_")
+	}
+
+	candidate := base
+	for i := 2; n[resourceType+"."+candidate]; i++ {
+		candidate = base + "_" + strconv.Itoa(i)
+	}
+	n[resourceType+"."+candidate] = true
+
+	return candidate
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+func TestExport(t *testing.T) {
+	api := fakeserver.New()
+	sloane := api.AddEngineer(client.Engineer{Id: "G63RN", Name: "sloane", Email: "sloane@finches.com"})
+	ryan := api.AddEngineer(client.Engineer{
+		Id: "UWJVB", Name: "Ryan O'Neil", Email: "ryan@finches.com",
+		Role: client.RoleSRE, Level: "senior", Skills: []string{"terraform", "go"},
+	})
+	api.AddEngineer(client.Engineer{Id: "X1", Name: "sloane", Email: "sloane2@finches.com"})
+	api.AddDev(client.Dev{
+		Id: "D1", Name: "dev_finches", Description: "Finches cohort",
+		Labels: map[string]string{"cohort": "2024", "track": "platform"}, OwnerEngineerId: ryan.Id,
+	}, sloane.Id, ryan.Id)
+	api.AddDev(client.Dev{Id: "D2", Name: "2nd team"})
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	files, err := export(client.NewClient(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"engineers.tf": `resource "devops-bootcamp_engineer_resource" "sloane" {
+  name  = "sloane"
+  email = "sloane@finches.com"
+}
+
+resource "devops-bootcamp_engineer_resource" "ryan_o_neil" {
+  name   = "Ryan O'Neil"
+  email  = "ryan@finches.com"
+  role   = "sre"
+  level  = "senior"
+  skills = ["go", "terraform"]
+}
+
+resource "devops-bootcamp_engineer_resource" "sloane_2" {
+  name  = "sloane"
+  email = "sloane2@finches.com"
+}
+`,
+		"devs.tf": `resource "devops-bootcamp_dev_resource" "dev_finches" {
+  name        = "dev_finches"
+  description = "Finches cohort"
+  labels = {
+    cohort = "2024"
+    track  = "platform"
+  }
+  owner_engineer_id = devops-bootcamp_engineer_resource.ryan_o_neil.id
+  engineers = [
+    { id = devops-bootcamp_engineer_resource.sloane.id },
+    { id = devops-bootcamp_engineer_resource.ryan_o_neil.id },
+  ]
+}
+
+resource "devops-bootcamp_dev_resource" "dev_2nd_team" {
+  name = "2nd team"
+}
+`,
+		"imports.tf": `import {
+  to = devops-bootcamp_engineer_resource.sloane
+  id = "G63RN"
+}
+
+import {
+  to = devops-bootcamp_engineer_resource.ryan_o_neil
+  id = "UWJVB"
+}
+
+import {
+  to = devops-bootcamp_engineer_resource.sloane_2
+  id = "X1"
+}
+
+import {
+  to = devops-bootcamp_dev_resource.dev_finches
+  id = "D1"
+}
+
+import {
+  to = devops-bootcamp_dev_resource.dev_2nd_team
+  id = "D2"
+}
+`,
+	}
+
+	for name, content := range want {
+		got := string(files[name])
+		if got != content {
+			t.Errorf("unexpected %s:\n%s\nwant:\n%s", name, got, content)
+		}
+		if _, diags := hclsyntax.ParseConfig(files[name], name, hcl.InitialPos); diags.HasErrors() {
+			t.Errorf("%s is not valid HCL: %s", name, diags)
+		}
+	}
+}
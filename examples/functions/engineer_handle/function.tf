@@ -0,0 +1,3 @@
+output "handle" {
+  value = provider::devops-bootcamp::engineer_handle("Sloane O'Brien")
+}
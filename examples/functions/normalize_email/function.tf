@@ -0,0 +1,3 @@
+output "email" {
+  value = provider::devops-bootcamp::normalize_email("  Sloane@Finches.COM ")
+}
@@ -0,0 +1,7 @@
+locals {
+  membership = provider::devops-bootcamp::parse_membership_id("DEV01/G63RN")
+}
+
+output "engineer_id" {
+  value = local.membership.engineer_id
+}
@@ -0,0 +1,5 @@
+# Devs can be imported by ID or by name. Prefix the name with "name:" when
+# it looks like another dev's ID.
+terraform import devops-bootcamp_dev_resource.finches D0001
+terraform import devops-bootcamp_dev_resource.finches dev_finches
+terraform import devops-bootcamp_dev_resource.finches name:dev_finches
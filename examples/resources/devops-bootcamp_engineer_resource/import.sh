@@ -0,0 +1,5 @@
+# Engineers can be imported by ID, by email or by name. Imports by email or
+# name fail when more than one engineer matches.
+terraform import devops-bootcamp_engineer_resource.alice G63RN
+terraform import devops-bootcamp_engineer_resource.alice email:alice@finches.com
+terraform import devops-bootcamp_engineer_resource.alice name:alice
@@ -0,0 +1,11 @@
+# roster.csv
+# name,email
+# sloane,sloane@finches.com
+# ryan,ryan@finches.com
+resource "devops-bootcamp_engineer_roster" "cohort" {
+  engineers = csvdecode(file("${path.module}/roster.csv"))
+}
+
+output "cohort_ids" {
+  value = { for email, member in devops-bootcamp_engineer_roster.cohort.members : email => member.id }
+}
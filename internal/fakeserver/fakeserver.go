@@ -0,0 +1,424 @@
+// Package fakeserver implements an in-memory DevOps Bootcamp API for tests.
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+)
+
+// Server is an in-memory DevOps Bootcamp API. It serves the same routes as
+// the bootcamp app and is safe for concurrent use.
+type Server struct {
+	mu        sync.Mutex
+	engineers map[string]*client.Engineer
+	devs      map[string]*client.Dev
+	// order keeps listings in creation order like the bootcamp app
+	order  []string
+	nextID int
+	// info is served on /version, which is missing while it is nil
+	info *client.ServerInfo
+	// writes counts the requests that were not GETs
+	writes int
+	// failing holds the engineer IDs whose dev membership changes fail
+	failing map[string]bool
+}
+
+// New returns an empty server.
+func New() *Server {
+	return &Server{
+		engineers: map[string]*client.Engineer{},
+		devs:      map[string]*client.Dev{},
+		info: &client.ServerInfo{
+			Version:  "1.2.0",
+			Features: []string{client.FeatureArchive, client.FeatureRemoveEngineer},
+		},
+	}
+}
+
+// SetServerInfo changes the version and features served on /version. A nil
+// info removes the endpoint, like servers that predate it.
+func (s *Server) SetServerInfo(info *client.ServerInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.info = info
+}
+
+// FailMembership makes adding the engineers with the given IDs to a dev, or
+// removing them from one, fail with a server error. Calling it again replaces
+// the failing engineers, and calling it with no IDs lets every change through.
+func (s *Server) FailMembership(engineerIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failing = make(map[string]bool, len(engineerIDs))
+	for _, id := range engineerIDs {
+		s.failing[id] = true
+	}
+}
+
+// Writes returns the number of requests other than GET the server received,
+// whether or not they succeeded.
+func (s *Server) Writes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.writes
+}
+
+// AddEngineer stores an engineer, generating its ID when empty, and returns it.
+func (s *Server) AddEngineer(engineer client.Engineer) client.Engineer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addEngineer(engineer)
+}
+
+// AddDev stores a dev with the given member engineer IDs, generating its ID
+// when empty, and returns it.
+func (s *Server) AddDev(dev client.Dev, engineerIDs ...string) client.Dev {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.addDev(dev)
+	for _, id := range engineerIDs {
+		if engineer, ok := s.engineers[id]; ok {
+			stored.Engineers = append(stored.Engineers, engineer)
+		}
+	}
+
+	return *s.copyDev(stored)
+}
+
+// Engineers returns every stored engineer in creation order.
+func (s *Server) Engineers() []client.Engineer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	engineers := []client.Engineer{}
+	for _, id := range s.order {
+		if engineer, ok := s.engineers[id]; ok {
+			engineers = append(engineers, *engineer)
+		}
+	}
+
+	return engineers
+}
+
+// Devs returns every stored dev in creation order.
+func (s *Server) Devs() []client.Dev {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	devs := []client.Dev{}
+	for _, id := range s.order {
+		if dev, ok := s.devs[id]; ok {
+			devs = append(devs, *s.copyDev(dev))
+		}
+	}
+
+	return devs
+}
+
+// ServeHTTP implements the bootcamp API routes.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		s.writes++
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch parts[0] {
+	case "engineers":
+		s.serveEngineers(w, r, parts[1:])
+	case "dev":
+		s.serveDevs(w, r, parts[1:])
+	case "version":
+		if s.info == nil || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.info)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveEngineers(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 0:
+		engineers := []*client.Engineer{}
+		for _, id := range s.order {
+			if engineer, ok := s.engineers[id]; ok {
+				engineers = append(engineers, engineer)
+			}
+		}
+		writeJSON(w, http.StatusOK, engineers)
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "id":
+		engineer, ok := s.engineers[parts[1]]
+		if !ok {
+			http.Error(w, "engineer not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, engineer)
+	case r.Method == http.MethodPost && len(parts) == 0:
+		var engineer client.Engineer
+		if !readJSON(w, r, &engineer) || !validRole(w, engineer.Role) {
+			return
+		}
+		engineer.Id = ""
+		writeJSON(w, http.StatusCreated, s.addEngineer(engineer))
+	case r.Method == http.MethodPut && len(parts) == 1:
+		engineer, ok := s.engineers[parts[0]]
+		if !ok {
+			http.Error(w, "engineer not found", http.StatusNotFound)
+			return
+		}
+		var update client.Engineer
+		if !readJSON(w, r, &update) || !validRole(w, update.Role) {
+			return
+		}
+		engineer.Name = update.Name
+		engineer.Email = update.Email
+		engineer.Role = update.Role
+		engineer.Level = update.Level
+		engineer.Skills = update.Skills
+		engineer.Labels = update.Labels
+		if update.Active != nil {
+			engineer.Active = update.Active
+		}
+		writeJSON(w, http.StatusOK, engineer)
+	case r.Method == http.MethodDelete && len(parts) == 1:
+		if _, ok := s.engineers[parts[0]]; !ok {
+			http.Error(w, "engineer not found", http.StatusNotFound)
+			return
+		}
+		// Deleting an engineer also removes it from every dev
+		delete(s.engineers, parts[0])
+		for _, dev := range s.devs {
+			removeMember(dev, parts[0])
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"id": parts[0]})
+	default:
+		http.Error(w, "unsupported engineers route", http.StatusNotFound)
+	}
+}
+
+func (s *Server) serveDevs(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 0:
+		devs := []*client.Dev{}
+		for _, id := range s.order {
+			if dev, ok := s.devs[id]; ok {
+				devs = append(devs, dev)
+			}
+		}
+		writeJSON(w, http.StatusOK, devs)
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "id":
+		dev, ok := s.devs[parts[1]]
+		if !ok {
+			http.Error(w, "dev not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, dev)
+	case r.Method == http.MethodPost && len(parts) == 0:
+		var dev client.Dev
+		if !readJSON(w, r, &dev) {
+			return
+		}
+		dev.Id = ""
+		dev.Engineers = nil
+		if !validOwner(w, &dev) {
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.addDev(dev))
+	case r.Method == http.MethodPost && len(parts) == 1:
+		// Adds an engineer to the dev
+		dev, ok := s.devs[parts[0]]
+		if !ok {
+			http.Error(w, "dev not found", http.StatusNotFound)
+			return
+		}
+		var payload struct {
+			Id string `json:"id"`
+		}
+		if !readJSON(w, r, &payload) || !s.membershipAllowed(w, payload.Id) {
+			return
+		}
+		engineer, ok := s.engineers[payload.Id]
+		if !ok {
+			http.Error(w, "engineer not found", http.StatusNotFound)
+			return
+		}
+		dev.Engineers = append(withoutEngineer(dev.Engineers, engineer.Id), engineer)
+		writeJSON(w, http.StatusOK, dev)
+	case r.Method == http.MethodPut && len(parts) == 1:
+		dev, ok := s.devs[parts[0]]
+		if !ok {
+			http.Error(w, "dev not found", http.StatusNotFound)
+			return
+		}
+		var update client.Dev
+		if !readJSON(w, r, &update) {
+			return
+		}
+		var members []*client.Engineer
+		for _, member := range update.Engineers {
+			if engineer, ok := s.engineers[member.Id]; ok {
+				members = append(members, engineer)
+			}
+		}
+		update.Id = dev.Id
+		update.Engineers = members
+		if !validOwner(w, &update) {
+			return
+		}
+		*dev = update
+		writeJSON(w, http.StatusOK, dev)
+	case r.Method == http.MethodDelete && len(parts) == 1:
+		if _, ok := s.devs[parts[0]]; !ok {
+			http.Error(w, "dev not found", http.StatusNotFound)
+			return
+		}
+		delete(s.devs, parts[0])
+		writeJSON(w, http.StatusOK, map[string]string{"id": parts[0]})
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		// Removes an engineer from the dev
+		dev, ok := s.devs[parts[0]]
+		if !ok {
+			http.Error(w, "dev not found", http.StatusNotFound)
+			return
+		}
+		if !s.membershipAllowed(w, parts[1]) {
+			return
+		}
+		removeMember(dev, parts[1])
+		writeJSON(w, http.StatusOK, dev)
+	default:
+		http.Error(w, "unsupported dev route", http.StatusNotFound)
+	}
+}
+
+// addEngineer stores an engineer. Callers must hold s.mu.
+func (s *Server) addEngineer(engineer client.Engineer) *client.Engineer {
+	if engineer.Id == "" {
+		engineer.Id = s.newID("E")
+	}
+	s.engineers[engineer.Id] = &engineer
+	s.order = append(s.order, engineer.Id)
+
+	return &engineer
+}
+
+// addDev stores a dev. Callers must hold s.mu.
+func (s *Server) addDev(dev client.Dev) *client.Dev {
+	if dev.Id == "" {
+		dev.Id = s.newID("D")
+	}
+	s.devs[dev.Id] = &dev
+	s.order = append(s.order, dev.Id)
+
+	return &dev
+}
+
+// copyDev returns a copy of dev whose engineers and labels are detached
+// from the server's. Callers must hold s.mu.
+func (s *Server) copyDev(dev *client.Dev) *client.Dev {
+	copied := *dev
+	copied.Engineers = nil
+	for _, engineer := range dev.Engineers {
+		member := *engineer
+		copied.Engineers = append(copied.Engineers, &member)
+	}
+	copied.Labels = maps.Clone(dev.Labels)
+
+	return &copied
+}
+
+// newID returns a deterministic five character ID. Callers must hold s.mu.
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%04d", prefix, s.nextID)
+}
+
+// removeMember removes an engineer from a dev, clearing the dev's owner when
+// it was that engineer.
+func removeMember(dev *client.Dev, id string) {
+	dev.Engineers = withoutEngineer(dev.Engineers, id)
+	if dev.OwnerEngineerId == id {
+		dev.OwnerEngineerId = ""
+	}
+}
+
+// membershipAllowed fails the request when changes to the membership of the
+// engineer with id were set to fail, writing the error response. Callers
+// must hold s.mu.
+func (s *Server) membershipAllowed(w http.ResponseWriter, id string) bool {
+	if !s.failing[id] {
+		return true
+	}
+
+	http.Error(w, fmt.Sprintf("membership of engineer %s cannot change", id), http.StatusInternalServerError)
+	return false
+}
+
+// validOwner rejects a dev owned by an engineer that is not one of its
+// engineers, writing the error response.
+func validOwner(w http.ResponseWriter, dev *client.Dev) bool {
+	if dev.OwnerEngineerId == "" {
+		return true
+	}
+	for _, engineer := range dev.Engineers {
+		if engineer.Id == dev.OwnerEngineerId {
+			return true
+		}
+	}
+
+	http.Error(w, fmt.Sprintf("owner %q is not an engineer of the dev", dev.OwnerEngineerId), http.StatusBadRequest)
+	return false
+}
+
+func withoutEngineer(engineers []*client.Engineer, id string) []*client.Engineer {
+	kept := []*client.Engineer{}
+	for _, engineer := range engineers {
+		if engineer.Id != id {
+			kept = append(kept, engineer)
+		}
+	}
+
+	return kept
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, target interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// validRole rejects roles the API does not know, writing the error response.
+func validRole(w http.ResponseWriter, role string) bool {
+	if role == "" || slices.Contains(client.Roles, role) {
+		return true
+	}
+
+	http.Error(w, fmt.Sprintf("unknown role %q", role), http.StatusBadRequest)
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -30,7 +31,8 @@ type devDataSource struct {
 
 // devDataSourceModel maps the data source schema data.
 type devDataSourceModel struct {
-	Devs []devModel `tfsdk:"devs"`
+	Labels types.Map  `tfsdk:"labels"`
+	Devs   []devModel `tfsdk:"devs"`
 }
 
 // devModel maps dev schema data.
@@ -39,6 +41,24 @@ type devModel struct {
 	Id          types.String     `tfsdk:"id"`
 	Engineers   []*engineerModel `tfsdk:"engineers"`
 	LastUpdated types.String     `tfsdk:"last_updated"`
+
+	Description     types.String `tfsdk:"description"`
+	Labels          types.Map    `tfsdk:"labels"`
+	OwnerEngineerId types.String `tfsdk:"owner_engineer_id"`
+}
+
+// labelsValue maps API labels to a map, keeping a dev without labels null.
+func labelsValue(labels map[string]string) types.Map {
+	if len(labels) == 0 {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(labels))
+	for key, value := range labels {
+		elements[key] = types.StringValue(value)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
 }
 
 // Metadata returns the data source type name.
@@ -53,6 +73,11 @@ func (d *devDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 		MarkdownDescription: "Devs data source",
 
 		Attributes: map[string]schema.Attribute{
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Only list devs carrying all of these labels",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 			"devs": schema.ListNestedAttribute{
 				MarkdownDescription: "Dev attribute",
 				Computed:            true,
@@ -89,12 +114,47 @@ func (d *devDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 										// 	stringplanmodifier.UseStateForUnknown(),
 										// },
 									},
+									"active": schema.BoolAttribute{
+										MarkdownDescription: "Whether the engineer is active",
+										Computed:            true,
+									},
+									"role": schema.StringAttribute{
+										MarkdownDescription: "Engineer role computed",
+										Computed:            true,
+									},
+									"level": schema.StringAttribute{
+										MarkdownDescription: "Engineer level computed",
+										Computed:            true,
+									},
+									"skills": schema.SetAttribute{
+										MarkdownDescription: "Engineer skills computed",
+										ElementType:         types.StringType,
+										Computed:            true,
+									},
+									"labels": schema.MapAttribute{
+										MarkdownDescription: "Engineer labels computed",
+										ElementType:         types.StringType,
+										Computed:            true,
+									},
 								},
 							},
 						},
 						"last_updated": schema.StringAttribute{
 							Computed: true,
 						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Dev description computed",
+							Computed:            true,
+						},
+						"labels": schema.MapAttribute{
+							MarkdownDescription: "Dev labels computed",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"owner_engineer_id": schema.StringAttribute{
+							MarkdownDescription: "Dev owner engineer id computed",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -104,9 +164,24 @@ func (d *devDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 
 // Read refreshes the Terraform state with the latest data.
 func (d *devDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_devs", "Read", "dev")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state devDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels map[string]string
+	diags = state.Labels.ElementsAs(ctx, &labels, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	devs, err := d.client.GetDevs()
+	devs, err := d.client.WithContext(ctx).GetDevs()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read DevOps Dev",
@@ -117,23 +192,24 @@ func (d *devDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	// Map response body to model
 	for _, dev := range devs {
+		if !dev.HasLabels(labels) {
+			continue
+		}
 		tempDev := devModel{
-			Id:   types.StringValue(dev.Id),
-			Name: types.StringValue(dev.Name),
+			Id:              types.StringValue(dev.Id),
+			Name:            types.StringValue(dev.Name),
+			Description:     optionalString(dev.Description),
+			Labels:          labelsValue(dev.Labels),
+			OwnerEngineerId: optionalString(dev.OwnerEngineerId),
 		}
 		for _, engineer := range dev.Engineers {
-			tempEngineer := engineerModel{
-				Id:    types.StringValue(engineer.Id),
-				Name:  types.StringValue(engineer.Name),
-				Email: types.StringValue(engineer.Email),
-			}
-			tempDev.Engineers = append(tempDev.Engineers, &tempEngineer)
+			tempDev.Engineers = append(tempDev.Engineers, newEngineerModel(engineer))
 		}
 		state.Devs = append(state.Devs, tempDev)
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -146,15 +222,15 @@ func (d *devDataSource) Configure(_ context.Context, req datasource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
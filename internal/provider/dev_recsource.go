@@ -4,25 +4,35 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
-
-	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch7/devops-resources"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &devResource{}
-	_ resource.ResourceWithConfigure   = &devResource{}
-	_ resource.ResourceWithImportState = &devResource{}
+	_ resource.Resource                   = &devResource{}
+	_ resource.ResourceWithConfigure      = &devResource{}
+	_ resource.ResourceWithImportState    = &devResource{}
+	_ resource.ResourceWithModifyPlan     = &devResource{}
+	_ resource.ResourceWithValidateConfig = &devResource{}
 )
 
 // NewDevResource is a helper function to simplify the provider implementation.
@@ -32,7 +42,9 @@ func NewDevResource() resource.Resource {
 
 // devResource is the resource implementation.
 type devResource struct {
-	client *client.Client
+	client              *client.Client
+	defaultLabels       map[string]string
+	maxTeamsPerEngineer int
 }
 
 // devResourceModel maps dev schema data.
@@ -42,6 +54,41 @@ type devResourceModel struct {
 	Id          types.String     `tfsdk:"id"`
 	Engineers   []*engineerModel `tfsdk:"engineers"`
 	LastUpdated types.String     `tfsdk:"last_updated"`
+
+	Description     types.String `tfsdk:"description"`
+	Labels          types.Map    `tfsdk:"labels"`
+	LabelsAll       types.Map    `tfsdk:"labels_all"`
+	OwnerEngineerId types.String `tfsdk:"owner_engineer_id"`
+
+	MinEngineers types.Int64 `tfsdk:"min_engineers"`
+	MaxEngineers types.Int64 `tfsdk:"max_engineers"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool `tfsdk:"force_destroy"`
+}
+
+// applyMetadata copies the name and metadata in the model onto dev.
+func (m devResourceModel) applyMetadata(ctx context.Context, dev *client.Dev) diag.Diagnostics {
+	dev.Name = m.Name.ValueString()
+	dev.Description = m.Description.ValueString()
+	dev.OwnerEngineerId = m.OwnerEngineerId.ValueString()
+	dev.Labels = nil
+
+	return m.LabelsAll.ElementsAs(ctx, &dev.Labels, false)
+}
+
+// setMetadata maps the name and metadata of an API dev to dev schema data.
+func (m *devResourceModel) setMetadata(dev *client.Dev) {
+	m.Name = types.StringValue(dev.Name)
+	m.Description = optionalString(dev.Description)
+	m.LabelsAll = labelsValue(dev.Labels)
+	m.OwnerEngineerId = optionalString(dev.OwnerEngineerId)
+}
+
+// metadataChanged reports whether the name or metadata differ between a and b.
+func metadataChanged(a, b devResourceModel) bool {
+	return !a.Name.Equal(b.Name) || !a.Description.Equal(b.Description) ||
+		!a.LabelsAll.Equal(b.LabelsAll) || !a.OwnerEngineerId.Equal(b.OwnerEngineerId)
 }
 
 // Metadata returns the resource type name.
@@ -65,8 +112,60 @@ func (r *devResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the dev.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Labels organizing the dev, such as its cohort or track. They are merged over the provider `default_labels`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"labels_all": schema.MapAttribute{
+				MarkdownDescription: "Labels of the dev, including the provider `default_labels`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"owner_engineer_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the engineer owning the dev. The owner must be one of the dev's `engineers`.",
+				Optional:            true,
+			},
+			"min_engineers": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of engineers the dev must have. Checked at plan time.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_engineers": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of engineers the dev may have. Checked at plan time.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtLeastSumOf(path.MatchRoot("min_engineers")),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to delete the dev while set. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Detach the dev's engineers when destroying it. Without it, destroying a dev that still has engineers fails. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"engineers": schema.ListNestedAttribute{
-				Optional: true,
+				MarkdownDescription: "Engineers of the dev. Each engineer may only be listed once.",
+				Optional:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -84,6 +183,38 @@ func (r *devResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
+						"active": schema.BoolAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.Bool{
+								boolplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"role": schema.StringAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"level": schema.StringAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"skills": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Set{
+								setplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"labels": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.UseStateForUnknown(),
+							},
+						},
 					},
 				},
 			},
@@ -91,8 +222,243 @@ func (r *devResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	}
 }
 
+// ModifyPlan merges the provider default labels into labels_all, plans the
+// engineers by ID, checks the planned engineers against the dev's size limits
+// and the provider max_teams_per_engineer, and fails any change while the
+// provider is read only.
+func (r *devResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		planLabelsAll(ctx, r.defaultLabels, req, resp)
+		planEngineers(ctx, req, resp)
+		r.checkCapacity(ctx, req, resp)
+	}
+
+	checkReadOnly(r.client, "dev", req, resp)
+}
+
+// planEngineers plans the computed attributes of each engineer from the
+// engineer with the same ID in state. UseStateForUnknown copies them by list
+// position, so an engineer replacing another at the same index would
+// otherwise be planned with the attributes of the one it replaced.
+func planEngineers(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var engineers types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+	if resp.Diagnostics.HasError() || engineers.IsNull() || engineers.IsUnknown() {
+		return
+	}
+
+	var planned, current []*engineerModel
+	resp.Diagnostics.Append(engineers.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("engineers"), &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("engineers"), matchEngineers(planned, current))...)
+}
+
+// matchEngineers returns the planned engineers with the computed attributes
+// of the current engineer with the same ID, or unknown ones for engineers
+// that are not current.
+func matchEngineers(planned, current []*engineerModel) []*engineerModel {
+	byID := make(map[string]*engineerModel, len(current))
+	for _, engineer := range current {
+		byID[engineer.Id.ValueString()] = engineer
+	}
+
+	matched := make([]*engineerModel, len(planned))
+	for index, engineer := range planned {
+		if prior, ok := byID[engineer.Id.ValueString()]; ok && !engineer.Id.IsUnknown() {
+			matched[index] = prior
+			continue
+		}
+		matched[index] = &engineerModel{
+			Id:     engineer.Id,
+			Name:   types.StringUnknown(),
+			Email:  types.StringUnknown(),
+			Active: types.BoolUnknown(),
+			Role:   types.StringUnknown(),
+			Level:  types.StringUnknown(),
+			Skills: types.SetUnknown(types.StringType),
+			Labels: types.MapUnknown(types.StringType),
+		}
+	}
+
+	return matched
+}
+
+// checkCapacity fails the plan when the planned engineers do not fit
+// min_engineers and max_engineers, or when an engineer added to the dev is
+// already in max_teams_per_engineer other devs.
+func (r *devResource) checkCapacity(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var engineers types.List
+	var minEngineers, maxEngineers types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("min_engineers"), &minEngineers)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_engineers"), &maxEngineers)...)
+	if resp.Diagnostics.HasError() || engineers.IsUnknown() {
+		return
+	}
+
+	count := int64(len(engineers.Elements()))
+	if !minEngineers.IsNull() && !minEngineers.IsUnknown() && count < minEngineers.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("engineers"),
+			"Too few engineers",
+			fmt.Sprintf("The dev has %d engineers, fewer than min_engineers (%d).", count, minEngineers.ValueInt64()),
+		)
+	}
+	if !maxEngineers.IsNull() && !maxEngineers.IsUnknown() && count > maxEngineers.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("engineers"),
+			"Too many engineers",
+			fmt.Sprintf("The dev has %d engineers, more than max_engineers (%d).", count, maxEngineers.ValueInt64()),
+		)
+	}
+
+	if r.client == nil || r.maxTeamsPerEngineer < 1 {
+		return
+	}
+
+	// Only engineers joining the dev can push it over the limit
+	var devID types.String
+	current := types.ListNull(engineers.ElementType(ctx))
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &devID)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("engineers"), &current)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	planned, _ := engineerIDs(engineers)
+	currentIDs, _ := engineerIDs(current)
+	added, _ := diffIDs(currentIDs, planned)
+	if len(added) == 0 {
+		return
+	}
+
+	devs, err := r.client.WithContext(ctx).GetDevs()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error sending get request to devops-bootcamp api",
+			"Could not read devs to check max_teams_per_engineer: "+err.Error(),
+		)
+		return
+	}
+
+	teams := engineerTeams(devs, devID.ValueString())
+	for _, ID := range added {
+		if len(teams[ID]) >= r.maxTeamsPerEngineer {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("engineers"),
+				"Engineer in too many teams",
+				fmt.Sprintf("Engineer Id %s is already in %d devs (%s). Adding it to this dev exceeds max_teams_per_engineer (%d).",
+					ID, len(teams[ID]), strings.Join(teams[ID], ", "), r.maxTeamsPerEngineer),
+			)
+		}
+	}
+}
+
+// engineerIDs returns the known engineer IDs of an engineers list, and
+// whether every ID is known.
+func engineerIDs(engineers types.List) ([]string, bool) {
+	if engineers.IsUnknown() {
+		return nil, false
+	}
+
+	known := true
+	var IDs []string
+	for _, element := range engineers.Elements() {
+		engineer, ok := element.(types.Object)
+		if !ok || engineer.IsUnknown() {
+			known = false
+			continue
+		}
+		ID, ok := engineer.Attributes()["id"].(types.String)
+		if !ok || ID.IsUnknown() {
+			known = false
+			continue
+		}
+		IDs = append(IDs, ID.ValueString())
+	}
+
+	return IDs, known
+}
+
+// engineerTeams maps engineer IDs to the names of the devs they are in,
+// leaving out the dev with ID devID.
+func engineerTeams(devs []client.Dev, devID string) map[string][]string {
+	teams := make(map[string][]string)
+	for _, dev := range devs {
+		if dev.Id == devID {
+			continue
+		}
+		for _, engineer := range dev.Engineers {
+			teams[engineer.Id] = append(teams[engineer.Id], dev.Name)
+		}
+	}
+
+	return teams
+}
+
+// ValidateConfig ensures every engineer is listed once and the owner is one
+// of the dev's engineers. Membership is diffed by ID, so a repeated engineer
+// would otherwise be silently attached once.
+func (r *devResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var owner types.String
+	var engineers types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("owner_engineer_id"), &owner)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("engineers"), &engineers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]int, len(engineers.Elements()))
+	for index, element := range engineers.Elements() {
+		engineer, ok := element.(types.Object)
+		if !ok || engineer.IsNull() || engineer.IsUnknown() {
+			continue
+		}
+		ID, ok := engineer.Attributes()["id"].(types.String)
+		if !ok || ID.IsNull() || ID.IsUnknown() {
+			continue
+		}
+		if first, ok := seen[ID.ValueString()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("engineers").AtListIndex(index).AtName("id"),
+				"Duplicate dev engineer",
+				fmt.Sprintf("The engineer %q is already listed as engineers[%d].", ID.ValueString(), first),
+			)
+			continue
+		}
+		seen[ID.ValueString()] = index
+	}
+
+	if owner.IsNull() || owner.IsUnknown() {
+		return
+	}
+
+	IDs, known := engineerIDs(engineers)
+	if !known || slices.Contains(IDs, owner.ValueString()) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("owner_engineer_id"),
+		"Owner is not an engineer of the dev",
+		fmt.Sprintf("The owner %q must also be listed in engineers.", owner.ValueString()),
+	)
+}
+
 // Create a new resource.
 func (r *devResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_dev_resource", "Create", "dev")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	log.Printf("Debug: Create request: %v", req)
 
 	// Retrieve values from plan
@@ -104,15 +470,22 @@ func (r *devResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	var devObject devops_resource.Dev
-	devObject.Name = plan.Name.ValueString()
+	var devObject client.Dev
+	diags = plan.applyMetadata(ctx, &devObject)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	devObject.Id = plan.Id.ValueString()
+	// The owner must already be an engineer of the dev, it is set once the
+	// engineers are attached
+	devObject.OwnerEngineerId = ""
 
 	// Print to standard logger; this will appear if TF_LOG=DEBUG is set
 	log.Printf("Debug: Dev Object: %#v", devObject)
 
 	// Create new dev
-	dev, err := r.client.CreateDev(devObject)
+	dev, err := r.client.WithContext(ctx).CreateDev(devObject)
 	if err != nil {
 		log.Printf("Error: %v", err)
 		resp.Diagnostics.AddError(
@@ -123,31 +496,20 @@ func (r *devResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	plan.Name = types.StringValue(dev.Name)
+	planned := plan
+	plan.setMetadata(dev)
 	plan.Id = types.StringValue(dev.Id)
-	for index, engineer := range plan.Engineers {
-		ID := strings.Trim(engineer.Id.String(), "\"")
+	setSpanEntityID(span, dev.Id)
 
-		eng, err := r.client.GetEngineer(ID)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error sending get request to devops-bootcamp api",
-				"Could not read engineer Id "+engineer.Id.String()+": "+err.Error(),
-			)
-			return
-		}
-		err = r.client.AddEngToDev(dev.Id, eng.Id)
+	// Attach the planned engineers concurrently, keeping the ones that made it
+	members := r.addEngineers(ctx, dev.Id, plan.Engineers, &resp.Diagnostics)
+	plan.Engineers = memberEngineers(plan.Engineers, nil, members)
 
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error sending get request to devops-bootcamp api",
-				"Could not add engineer Id "+engineer.Id.String()+" to Dev "+dev.Id+": "+err.Error(),
-			)
-			return
+	if !planned.OwnerEngineerId.IsNull() {
+		planned.Engineers = plan.Engineers
+		if updated := r.updateMetadata(ctx, dev.Id, planned, &resp.Diagnostics); updated != nil {
+			plan.setMetadata(updated)
 		}
-		plan.Engineers[index].Name = types.StringValue(eng.Name)
-		plan.Engineers[index].Id = types.StringValue(eng.Id)
-		plan.Engineers[index].Email = types.StringValue(eng.Email)
 	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -162,6 +524,9 @@ func (r *devResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 // Read resource information.
 func (r *devResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_dev_resource", "Read", "dev")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	log.Printf("Debug: Read request: %v", req)
 	// Get current state
 	var state devResourceModel
@@ -172,8 +537,10 @@ func (r *devResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	setSpanEntityID(span, state.Id.ValueString())
+
 	// Get refreshed dev value from HashiCups
-	dev, err := r.client.GetDev(state.Id.ValueString())
+	dev, err := r.client.WithContext(ctx).GetDev(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error sending get request to devops-bootcamp api",
@@ -183,13 +550,20 @@ func (r *devResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	state.Name = types.StringValue(dev.Name)
+	state.setMetadata(dev)
+	state.Labels = configuredLabels(dev.Labels, state.Labels, r.defaultLabels)
 	state.Id = types.StringValue(dev.Id)
-	for index, engineer := range dev.Engineers {
-		state.Engineers[index].Name = types.StringValue(engineer.Name)
-		state.Engineers[index].Id = types.StringValue(engineer.Id)
-		state.Engineers[index].Email = types.StringValue(engineer.Email)
+
+	// Rebuild the membership from the server, keeping the state order so
+	// engineers attached or detached outside of Terraform show up as drift
+	live := make([]*engineerModel, 0, len(dev.Engineers))
+	members := make(map[string]*engineerModel, len(dev.Engineers))
+	for _, engineer := range dev.Engineers {
+		member := newEngineerModel(engineer)
+		live = append(live, member)
+		members[engineer.Id] = member
 	}
+	state.Engineers = memberEngineers(state.Engineers, live, members)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -200,67 +574,242 @@ func (r *devResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+// Engineer membership is diffed between state and plan so only the engineers
+// that were added or removed are touched on the server.
 func (r *devResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_dev_resource", "Update", "dev")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	log.Printf("Debug: Update request: %v", req)
-	// Retrieve values from plan
-	var plan devResourceModel
+	// Retrieve values from plan and current state
+	var plan, state devResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		log.Printf("Error: %v", resp.Diagnostics)
 		return
 	}
 
-	var dev devops_resource.Dev
-	// Update dev
-	dev.Name = plan.Name.ValueString()
-	dev.Id = plan.Id.ValueString()
-	for _, engineer := range plan.Engineers {
-		ID := strings.Trim(engineer.Id.String(), "\"")
-		eng, err := r.client.GetEngineer(ID)
+	devID := state.Id.ValueString()
+	setSpanEntityID(span, devID)
+
+	// members holds the engineers currently attached to the dev on the server
+	members := make(map[string]*engineerModel, len(state.Engineers))
+	for _, engineer := range state.Engineers {
+		members[engineer.Id.ValueString()] = engineer
+	}
+
+	added, removed := diffEngineers(state.Engineers, plan.Engineers)
+	if len(removed) > 0 && !r.client.Supports(client.FeatureRemoveEngineer) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("engineers"),
+			"Removing engineers not supported",
+			removeEngineerUnsupported(r.client, devID),
+		)
+		removed = nil
+	}
+
+	for _, ID := range removed {
+		err := r.client.WithContext(ctx).RemoveEngFromDev(devID, ID)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error sending get request to devops-bootcamp api",
-				"Could not read engineer Id "+engineer.Id.String()+": "+err.Error(),
+			resp.Diagnostics.AddAttributeError(
+				path.Root("engineers"),
+				"Error sending delete request to devops-bootcamp api",
+				"Could not remove engineer Id "+ID+" from Dev "+devID+": "+err.Error(),
 			)
-			return
+			continue
 		}
-		dev.Engineers = append(dev.Engineers, eng)
+		delete(members, ID)
+	}
 
+	for ID, engineer := range r.addEngineers(ctx, devID, engineersByID(plan.Engineers, added), &resp.Diagnostics) {
+		members[ID] = engineer
 	}
 
-	devObj, err := r.client.UpdateDev(dev)
+	// Record the membership as it now exists on the server. On a partial
+	// failure this differs from the plan, and the next plan retries the rest.
+	plan.Id = state.Id
+	plan.Engineers = memberEngineers(plan.Engineers, state.Engineers, members)
+
+	// Update the name and metadata once the engineers are in place, as the
+	// owner must be one of them
+	if metadataChanged(plan, state) {
+		if updated := r.updateMetadata(ctx, devID, plan, &resp.Diagnostics); updated != nil {
+			plan.setMetadata(updated)
+		} else {
+			// Nothing has changed on the server, keep the prior metadata
+			plan.Name = state.Name
+			plan.Description = state.Description
+			plan.Labels = state.Labels
+			plan.LabelsAll = state.LabelsAll
+			plan.OwnerEngineerId = state.OwnerEngineerId
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		plan.LastUpdated = state.LastUpdated
+	} else {
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	}
 
+	// Set state to the membership the server now holds
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		log.Printf("Error: %v", resp.Diagnostics)
+		return
+	}
+}
+
+// updateMetadata sets the name and metadata of the dev from the model. The
+// update replaces the whole dev, so it sends the engineers in the model too,
+// which must be the membership the resource just reconciled rather than a
+// copy read back from the server. It returns the updated dev, or nil after
+// recording a diagnostic.
+func (r *devResource) updateMetadata(ctx context.Context, devID string, model devResourceModel, diags *diag.Diagnostics) *client.Dev {
+	dev := client.Dev{Id: devID}
+	modelDiags := model.applyMetadata(ctx, &dev)
+	for _, member := range model.Engineers {
+		engineer, engineerDiags := member.engineer(ctx)
+		modelDiags.Append(engineerDiags...)
+		dev.Engineers = append(dev.Engineers, &engineer)
+	}
+	if modelDiags.HasError() {
+		diags.Append(modelDiags...)
+		return nil
+	}
+
+	updated, err := r.client.WithContext(ctx).UpdateDev(dev)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error updating dev",
 			"Could not update dev, unexpected error: "+err.Error(),
 		)
-		return
+		return nil
 	}
 
-	// Map response body to schema and populate Computed attribute values
-	plan.Name = types.StringValue(devObj.Name)
-	plan.Id = types.StringValue(devObj.Id)
-	for index, eng := range devObj.Engineers {
-		plan.Engineers[index].Name = types.StringValue(eng.Name)
-		plan.Engineers[index].Id = types.StringValue(eng.Id)
-		plan.Engineers[index].Email = types.StringValue(eng.Email)
+	return updated
+}
+
+// addEngineers resolves and attaches engineers to the dev concurrently. It
+// returns the engineers that were attached keyed by ID and records a
+// diagnostic for every engineer that could not be.
+func (r *devResource) addEngineers(ctx context.Context, devID string, engineers []*engineerModel, diags *diag.Diagnostics) map[string]*engineerModel {
+	IDs := modelIDs(engineers)
+	added, errs := r.client.WithContext(ctx).AddEngsToDev(devID, IDs)
+
+	members := make(map[string]*engineerModel, len(IDs))
+	for index, ID := range IDs {
+		if errs[index] != nil {
+			diags.AddAttributeError(
+				path.Root("engineers"),
+				"Error adding engineer to dev",
+				"Could not add engineer Id "+ID+" to Dev "+devID+": "+errs[index].Error(),
+			)
+			continue
+		}
+		members[ID] = newEngineerModel(added[index])
 	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		log.Printf("Error: %v", resp.Diagnostics)
-		return
+	return members
+}
+
+// removeEngineerUnsupported explains that the server cannot remove engineers
+// from a dev.
+func removeEngineerUnsupported(c *client.Client, devID string) string {
+	return fmt.Sprintf("The DevOps Bootcamp API version %s cannot remove engineers from a dev, so Dev Id %s keeps its engineers. "+
+		"Upgrade the API, or delete the engineers instead.", c.Server.Version, devID)
+}
+
+// engineersByID returns the engineers from the list with the given IDs.
+func engineersByID(engineers []*engineerModel, IDs []string) []*engineerModel {
+	wanted := make(map[string]bool, len(IDs))
+	for _, ID := range IDs {
+		wanted[ID] = true
 	}
+
+	var matched []*engineerModel
+	for _, engineer := range engineers {
+		if ID := engineer.Id.ValueString(); wanted[ID] {
+			matched = append(matched, engineer)
+			delete(wanted, ID)
+		}
+	}
+
+	return matched
+}
+
+// diffEngineers returns the engineer IDs present in planned but not in
+// current, and those present in current but not in planned, in list order.
+func diffEngineers(current, planned []*engineerModel) (added, removed []string) {
+	return diffIDs(modelIDs(current), modelIDs(planned))
+}
+
+// diffIDs returns the IDs present in planned but not in current, and those
+// present in current but not in planned, in list order.
+func diffIDs(current, planned []string) (added, removed []string) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, ID := range current {
+		inCurrent[ID] = true
+	}
+	inPlanned := make(map[string]bool, len(planned))
+	for _, ID := range planned {
+		if !inCurrent[ID] && !inPlanned[ID] {
+			added = append(added, ID)
+		}
+		inPlanned[ID] = true
+	}
+	for _, ID := range current {
+		if !inPlanned[ID] {
+			removed = append(removed, ID)
+		}
+	}
+
+	return added, removed
+}
+
+// modelIDs returns the IDs of engineers.
+func modelIDs(engineers []*engineerModel) []string {
+	IDs := make([]string, len(engineers))
+	for index, engineer := range engineers {
+		IDs[index] = engineer.Id.ValueString()
+	}
+
+	return IDs
+}
+
+// memberEngineers orders the engineers in members by the planned list,
+// followed by any current engineers that are still attached but no longer
+// planned. An empty planned list stays empty rather than becoming null.
+func memberEngineers(planned, current []*engineerModel, members map[string]*engineerModel) []*engineerModel {
+	var engineers []*engineerModel
+	if planned != nil {
+		engineers = []*engineerModel{}
+	}
+
+	seen := make(map[string]bool, len(members))
+	for _, list := range [][]*engineerModel{planned, current} {
+		for _, engineer := range list {
+			ID := engineer.Id.ValueString()
+			if member, ok := members[ID]; ok && !seen[ID] {
+				engineers = append(engineers, member)
+				seen[ID] = true
+			}
+		}
+	}
+
+	return engineers
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
+// Protected devs are never deleted, and devs that still have engineers are
+// only deleted when force_destroy detaches the engineers first.
 func (r *devResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_dev_resource", "Delete", "dev")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	// Retrieve values from state
 	var state devResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -269,8 +818,79 @@ func (r *devResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	setSpanEntityID(span, state.Id.ValueString())
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Dev is protected from deletion",
+			"Dev Id "+state.Id.ValueString()+" has deletion_protection enabled. Set deletion_protection = false and apply before destroying it.",
+		)
+		return
+	}
+
+	// Check the membership on the server, it may have changed since the last refresh
+	dev, err := r.client.WithContext(ctx).GetDev(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error sending get request to devops-bootcamp api",
+			"Could not read dev Id "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(dev.Engineers) > 0 {
+		IDs := make([]string, len(dev.Engineers))
+		for index, engineer := range dev.Engineers {
+			IDs[index] = engineer.Id
+		}
+
+		if !state.ForceDestroy.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("force_destroy"),
+				"Dev still has engineers",
+				"Dev Id "+state.Id.ValueString()+" still has engineers "+strings.Join(IDs, ", ")+". "+
+					"Remove them from the dev, or set force_destroy = true and apply, before destroying it.",
+			)
+			return
+		}
+
+		if !r.client.Supports(client.FeatureRemoveEngineer) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("force_destroy"),
+				"Removing engineers not supported",
+				removeEngineerUnsupported(r.client, state.Id.ValueString()),
+			)
+			return
+		}
+
+		// Detach every engineer before deleting the dev
+		var remaining []*engineerModel
+		members := make(map[string]*engineerModel, len(IDs))
+		for index, err := range r.client.WithContext(ctx).RemoveEngsFromDev(dev.Id, IDs) {
+			if err == nil {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("engineers"),
+				"Error sending delete request to devops-bootcamp api",
+				"Could not remove engineer Id "+IDs[index]+" from Dev "+dev.Id+": "+err.Error(),
+			)
+			engineer := newEngineerModel(dev.Engineers[index])
+			remaining = append(remaining, engineer)
+			members[IDs[index]] = engineer
+		}
+		if resp.Diagnostics.HasError() {
+			// Keep the dev with the engineers that are still attached
+			state.Engineers = memberEngineers(state.Engineers, remaining, members)
+			diags = resp.State.Set(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
 	// Delete existing dev
-	err := r.client.DeleteDev(state.Id.String())
+	err = r.client.WithContext(ctx).DeleteDev(state.Id.String())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting dev",
@@ -286,21 +906,94 @@ func (r *devResource) Configure(_ context.Context, req resource.ConfigureRequest
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.defaultLabels = data.DefaultLabels
+	r.maxTeamsPerEngineer = data.MaxTeamsPerEngineer
 }
 
+// ImportState imports a dev by ID or by name, with its engineers. A
+// "name:" prefix only matches names, for dev names that look like IDs.
 func (r *devResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, span := startSpan(ctx, "devops-bootcamp_dev_resource", "ImportState", "dev")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	devs, err := r.client.WithContext(ctx).GetDevs()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error sending get request to devops-bootcamp api",
+			"Could not read devs: "+err.Error(),
+		)
+		return
+	}
+
+	dev, err := findDev(devs, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import dev", err.Error())
+		return
+	}
+
+	engineers := make([]*engineerModel, 0, len(dev.Engineers))
+	for _, engineer := range dev.Engineers {
+		engineers = append(engineers, newEngineerModel(engineer))
+	}
+
+	setSpanEntityID(span, dev.Id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dev.Id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), dev.Name)...)
+	if dev.Description != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("description"), dev.Description)...)
+	}
+	if dev.OwnerEngineerId != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner_engineer_id"), dev.OwnerEngineerId)...)
+	}
+	if len(engineers) > 0 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("engineers"), engineers)...)
+	}
+	// Defaults are not applied on import
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// findDev finds the dev an import ID refers to, preferring an ID match over
+// a name match.
+func findDev(devs []client.Dev, importID string) (*client.Dev, error) {
+	name, byName := strings.CutPrefix(importID, "name:")
+	if !byName {
+		for index := range devs {
+			if devs[index].Id == importID {
+				return &devs[index], nil
+			}
+		}
+	}
+
+	var matched []*client.Dev
+	for index := range devs {
+		if devs[index].Name == name {
+			matched = append(matched, &devs[index])
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no dev has the ID or name %q", importID)
+	case 1:
+		return matched[0], nil
+	default:
+		IDs := make([]string, len(matched))
+		for index, dev := range matched {
+			IDs[index] = dev.Id
+		}
+		return nil, fmt.Errorf("%d devs are named %q, import one of them by ID instead: %s", len(matched), name, strings.Join(IDs, ", "))
+	}
 }
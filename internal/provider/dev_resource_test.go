@@ -0,0 +1,757 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+func TestAccDevResourceImport(t *testing.T) {
+	server := httptest.NewServer(fakeserver.New())
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(`
+# Import takes the order the server lists the engineers in, so attach them
+# one at a time in the planned order
+provider "devops-bootcamp" {
+  host        = %q
+  parallelism = 1
+}
+
+resource "devops-bootcamp_engineer_resource" "sloane" {
+  name  = "sloane"
+  email = "sloane@finches.com"
+}
+
+resource "devops-bootcamp_engineer_resource" "ryan" {
+  name  = "ryan"
+  email = "ryan@finches.com"
+}
+
+resource "devops-bootcamp_dev_resource" "test" {
+  name          = "dev_finches"
+  force_destroy = true
+  engineers = [
+    { id = devops-bootcamp_engineer_resource.sloane.id },
+    { id = devops-bootcamp_engineer_resource.ryan.id },
+  ]
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "engineers.#", "2"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "engineers.0.name", "sloane"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "engineers.1.email", "ryan@finches.com"),
+				),
+			},
+			// ImportState testing by ID
+			{
+				ResourceName:            "devops-bootcamp_dev_resource.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "deletion_protection", "force_destroy"},
+			},
+			// ImportState testing by name
+			{
+				ResourceName:            "devops-bootcamp_dev_resource.test",
+				ImportState:             true,
+				ImportStateId:           "dev_finches",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "deletion_protection", "force_destroy"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDevResourceMetadata(t *testing.T) {
+	server := httptest.NewServer(fakeserver.New())
+	defer server.Close()
+
+	config := func(dev string) string {
+		return fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host = %q
+}
+
+resource "devops-bootcamp_engineer_resource" "sloane" {
+  name  = "sloane"
+  email = "sloane@finches.com"
+}
+
+resource "devops-bootcamp_engineer_resource" "ryan" {
+  name  = "ryan"
+  email = "ryan@finches.com"
+}
+
+resource "devops-bootcamp_dev_resource" "test" {
+  name          = "dev_finches"
+  force_destroy = true
+%s
+}
+`, server.URL, dev)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The owner must be one of the engineers
+			{
+				Config: config(`
+  owner_engineer_id = "G63RN"
+  engineers         = [{ id = "UWJVB" }]
+`),
+				ExpectError: regexp.MustCompile(`Owner is not an engineer of the dev`),
+			},
+			// Every engineer is listed once
+			{
+				Config: config(`
+  engineers = [{ id = "G63RN" }, { id = "UWJVB" }, { id = "G63RN" }]
+`),
+				ExpectError: regexp.MustCompile(`Duplicate dev engineer`),
+			},
+			// Create and Read testing
+			{
+				Config: config(`
+  description       = "Finches cohort"
+  labels            = { cohort = "2024", track = "platform" }
+  owner_engineer_id = devops-bootcamp_engineer_resource.sloane.id
+  engineers         = [{ id = devops-bootcamp_engineer_resource.sloane.id }]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "description", "Finches cohort"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "labels.cohort", "2024"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_dev_resource.test", "owner_engineer_id", "devops-bootcamp_engineer_resource.sloane", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "devops-bootcamp_dev_resource.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "deletion_protection", "force_destroy"},
+			},
+			// Hand the dev over to a new member, dropping the previous owner
+			{
+				Config: config(`
+  labels            = { cohort = "2024", track = "web" }
+  owner_engineer_id = devops-bootcamp_engineer_resource.ryan.id
+  engineers         = [{ id = devops-bootcamp_engineer_resource.ryan.id }]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("devops-bootcamp_dev_resource.test", "description"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "labels.track", "web"),
+					resource.TestCheckResourceAttrPair("devops-bootcamp_dev_resource.test", "owner_engineer_id", "devops-bootcamp_engineer_resource.ryan", "id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "engineers.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDevResourceCapacity(t *testing.T) {
+	api := fakeserver.New()
+	busy := api.AddEngineer(client.Engineer{Id: "G63RN", Name: "sloane", Email: "sloane@finches.com"})
+	api.AddEngineer(client.Engineer{Id: "UWJVB", Name: "ryan", Email: "ryan@finches.com"})
+	api.AddEngineer(client.Engineer{Id: "X1", Name: "tina", Email: "tina@finches.com"})
+	api.AddDev(client.Dev{Id: "D1", Name: "dev_finches"}, busy.Id)
+	api.AddDev(client.Dev{Id: "D2", Name: "dev_twins"}, busy.Id)
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(dev string) string {
+		return fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host                   = %q
+  max_teams_per_engineer = 2
+}
+
+resource "devops-bootcamp_dev_resource" "test" {
+  name          = "dev_capacity"
+  force_destroy = true
+%s
+}
+`, server.URL, dev)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The limits must leave room for the minimum
+			{
+				Config: config(`
+  min_engineers = 3
+  max_engineers = 2
+`),
+				ExpectError: regexp.MustCompile(`at least sum of`),
+			},
+			// Too few and too many engineers
+			{
+				Config: config(`
+  min_engineers = 2
+  engineers     = [{ id = "UWJVB" }]
+`),
+				ExpectError: regexp.MustCompile(`fewer than min_engineers`),
+			},
+			{
+				Config: config(`
+  max_engineers = 1
+  engineers     = [{ id = "UWJVB" }, { id = "X1" }]
+`),
+				ExpectError: regexp.MustCompile(`more than max_engineers`),
+			},
+			// An engineer already in two devs cannot join a third
+			{
+				Config: config(`
+  engineers = [{ id = "G63RN" }]
+`),
+				ExpectError: regexp.MustCompile(`G63RN is already in 2 devs`),
+			},
+			// Create and Read testing
+			{
+				Config: config(`
+  min_engineers = 1
+  max_engineers = 2
+  engineers     = [{ id = "UWJVB" }]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "engineers.#", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "max_engineers", "2"),
+				),
+			},
+			// Adding the busy engineer on update is rejected too
+			{
+				Config: config(`
+  min_engineers = 1
+  max_engineers = 2
+  engineers     = [{ id = "UWJVB" }, { id = "G63RN" }]
+`),
+				ExpectError: regexp.MustCompile(`Engineer in too many teams`),
+			},
+		},
+	})
+}
+
+func TestAccDevResourceDeletion(t *testing.T) {
+	api := fakeserver.New()
+	api.AddEngineer(client.Engineer{Id: "G63RN", Name: "sloane", Email: "sloane@finches.com"})
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(dev string) string {
+		config := fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host = %q
+}
+`, server.URL)
+		if dev == "" {
+			return config
+		}
+		return config + fmt.Sprintf(`
+resource "devops-bootcamp_dev_resource" "test" {
+  name      = "dev_finches"
+  engineers = [{ id = "G63RN" }]
+%s
+}
+`, dev)
+	}
+
+	// checkServerDevs checks how many devs the server holds, and that the
+	// engineer is never deleted with them.
+	checkServerDevs := func(want int) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if got := len(api.Devs()); got != want {
+				return fmt.Errorf("expected %d devs on the server, got %d", want, got)
+			}
+			if got := len(api.Engineers()); got != 1 {
+				return fmt.Errorf("expected the engineer to be kept, got %d engineers", got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(`  deletion_protection = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "force_destroy", "false"),
+					checkServerDevs(1),
+				),
+			},
+			// A protected dev refuses to be destroyed
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`Dev is protected from deletion`),
+			},
+			{
+				Config: config(`  deletion_protection = false`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "deletion_protection", "false"),
+					checkServerDevs(1),
+				),
+			},
+			// A dev with engineers refuses to be destroyed without force_destroy
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`Dev still has engineers`),
+			},
+			{
+				Config: config(`  force_destroy = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "force_destroy", "true"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "engineers.#", "1"),
+					checkServerDevs(1),
+				),
+			},
+			// force_destroy detaches the engineers, then deletes the dev
+			{
+				Config: config(""),
+				Check:  checkServerDevs(0),
+			},
+		},
+	})
+}
+
+func TestEngineerTeams(t *testing.T) {
+	devs := []client.Dev{
+		{Id: "D1", Name: "dev_finches", Engineers: []*client.Engineer{{Id: "G63RN"}, {Id: "UWJVB"}}},
+		{Id: "D2", Name: "dev_twins", Engineers: []*client.Engineer{{Id: "G63RN"}}},
+		{Id: "D3", Name: "dev_capacity", Engineers: []*client.Engineer{{Id: "G63RN"}}},
+	}
+
+	teams := engineerTeams(devs, "D3")
+	if got := teams["G63RN"]; !reflect.DeepEqual(got, []string{"dev_finches", "dev_twins"}) {
+		t.Errorf("teams of G63RN = %v, want [dev_finches dev_twins]", got)
+	}
+	if got := teams["UWJVB"]; !reflect.DeepEqual(got, []string{"dev_finches"}) {
+		t.Errorf("teams of UWJVB = %v, want [dev_finches]", got)
+	}
+	if got := teams["X1"]; got != nil {
+		t.Errorf("teams of X1 = %v, want none", got)
+	}
+}
+
+func TestFindDev(t *testing.T) {
+	devs := []client.Dev{
+		{Id: "D1", Name: "finches"},
+		{Id: "D2", Name: "D1"},
+		{Id: "D3", Name: "twins"},
+		{Id: "D4", Name: "twins"},
+	}
+
+	tests := []struct {
+		importID string
+		wantID   string
+		wantErr  string
+	}{
+		{importID: "D1", wantID: "D1"},
+		{importID: "finches", wantID: "D1"},
+		{importID: "name:D1", wantID: "D2"},
+		{importID: "twins", wantErr: `2 devs are named "twins", import one of them by ID instead: D3, D4`},
+		{importID: "missing", wantErr: `no dev has the ID or name "missing"`},
+	}
+
+	for _, test := range tests {
+		dev, err := findDev(devs, test.importID)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("findDev(%q) error = %v, want %q", test.importID, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("findDev(%q) unexpected error: %s", test.importID, err)
+			continue
+		}
+		if dev.Id != test.wantID {
+			t.Errorf("findDev(%q) = %s, want %s", test.importID, dev.Id, test.wantID)
+		}
+	}
+}
+
+// uniqueEngineers drops engineers whose ID was already seen, as the API
+// never lists the same engineer twice.
+func uniqueEngineers(engineers []client.Engineer) []client.Engineer {
+	seen := make(map[string]bool, len(engineers))
+	var unique []client.Engineer
+	for _, engineer := range engineers {
+		if !seen[engineer.Id] {
+			unique = append(unique, engineer)
+			seen[engineer.Id] = true
+		}
+	}
+
+	return unique
+}
+
+// uniqueIDs drops repeated IDs, as the state never holds an engineer twice.
+func uniqueIDs(IDs []string) []string {
+	seen := make(map[string]bool, len(IDs))
+	var unique []string
+	for _, ID := range IDs {
+		if !seen[ID] {
+			unique = append(unique, ID)
+			seen[ID] = true
+		}
+	}
+
+	return unique
+}
+
+// sameSkills reports whether a and b hold the same skills, ignoring order
+// and repeats as a set does.
+func sameSkills(a, b []string) bool {
+	set := func(skills []string) map[string]bool {
+		unique := make(map[string]bool, len(skills))
+		for _, skill := range skills {
+			unique[skill] = true
+		}
+		return unique
+	}
+
+	return reflect.DeepEqual(set(a), set(b))
+}
+
+// sameLabels reports whether a and b hold the same labels, treating nil and
+// empty alike as the API omits empty labels.
+func sameLabels(a, b map[string]string) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
+// smallIDs maps random bytes to IDs from a small alphabet, so generated
+// lists share and repeat engineers as often as real ones do.
+func smallIDs(values []uint8) []string {
+	IDs := make([]string, len(values))
+	for index, value := range values {
+		IDs[index] = fmt.Sprintf("E%d", value%5)
+	}
+
+	return IDs
+}
+
+func idModels(IDs []string) []*engineerModel {
+	engineers := make([]*engineerModel, len(IDs))
+	for index, ID := range IDs {
+		engineers[index] = &engineerModel{Id: types.StringValue(ID)}
+	}
+
+	return engineers
+}
+
+// sameEngineer reports whether a and b have the same attributes, apart from
+// whether they are active.
+func sameEngineer(a, b client.Engineer) bool {
+	return a.Name == b.Name && a.Id == b.Id && a.Email == b.Email && a.Role == b.Role && a.Level == b.Level &&
+		sameSkills(a.Skills, b.Skills) && sameLabels(a.Labels, b.Labels)
+}
+
+// TestEngineerModelRoundTrip checks that mapping an API engineer to schema
+// data and back keeps every attribute the provider sends, that the dev's
+// nested engineers map it the same way and back, and that planning engineers
+// by ID keeps the planned order.
+func TestEngineerModelRoundTrip(t *testing.T) {
+	roundTrip := func(engineers []client.Engineer) bool {
+		engineers = uniqueEngineers(engineers)
+
+		IDs := make([]string, len(engineers))
+		members := make(map[string]*engineerModel, len(engineers))
+		for index := range engineers {
+			IDs[index] = engineers[index].Id
+			members[engineers[index].Id] = newEngineerModel(&engineers[index])
+		}
+
+		got := memberEngineers(idModels(IDs), nil, members)
+		if len(got) != len(engineers) {
+			return false
+		}
+		for index, nested := range got {
+			original := engineers[index]
+
+			var model engineerResourceModel
+			model.setEngineer(&original)
+			back, diags := model.engineer(context.Background())
+			if diags.HasError() || !sameEngineer(back, original) {
+				return false
+			}
+
+			if !nested.Name.Equal(model.Name) || !nested.Id.Equal(model.Id) || !nested.Email.Equal(model.Email) ||
+				!nested.Active.Equal(model.Active) || !nested.Role.Equal(model.Role) || !nested.Level.Equal(model.Level) ||
+				!nested.Skills.Equal(model.Skills) || !nested.Labels.Equal(model.LabelsAll) {
+				return false
+			}
+
+			// The dev sends its nested engineers back when updating it
+			back, diags = nested.engineer(context.Background())
+			if diags.HasError() || !sameEngineer(back, original) || back.IsActive() != original.IsActive() {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestMemberEngineersDrift checks that engineers attached outside of
+// Terraform follow the planned ones, and that detached ones are dropped.
+func TestMemberEngineersDrift(t *testing.T) {
+	drift := func(plannedValues, liveValues []uint8) bool {
+		planned, live := smallIDs(plannedValues), smallIDs(liveValues)
+		members := make(map[string]*engineerModel, len(live))
+		for _, engineer := range idModels(live) {
+			members[engineer.Id.ValueString()] = engineer
+		}
+
+		var want []string
+		seen := make(map[string]bool)
+		for _, ID := range append(append([]string{}, planned...), live...) {
+			if members[ID] != nil && !seen[ID] {
+				want = append(want, ID)
+				seen[ID] = true
+			}
+		}
+
+		got := modelIDs(memberEngineers(idModels(planned), idModels(live), members))
+		return len(got) == len(want) && (len(got) == 0 || reflect.DeepEqual(got, want))
+	}
+
+	if err := quick.Check(drift, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestDiffEngineers checks that applying the diff to the current engineers
+// yields exactly the planned engineers, adding and removing each at most once.
+func TestDiffEngineers(t *testing.T) {
+	apply := func(currentValues, plannedValues []uint8) bool {
+		current, planned := uniqueIDs(smallIDs(currentValues)), smallIDs(plannedValues)
+		added, removed := diffEngineers(idModels(current), idModels(planned))
+
+		result := make(map[string]bool)
+		for _, ID := range current {
+			result[ID] = true
+		}
+		for _, ID := range removed {
+			if !result[ID] {
+				return false
+			}
+			delete(result, ID)
+		}
+		for _, ID := range added {
+			if result[ID] {
+				return false
+			}
+			result[ID] = true
+		}
+
+		want := make(map[string]bool)
+		for _, ID := range planned {
+			want[ID] = true
+		}
+
+		return reflect.DeepEqual(result, want)
+	}
+
+	if err := quick.Check(apply, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDiffIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     []string
+		planned     []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{name: "unchanged", current: []string{"A", "B"}, planned: []string{"A", "B"}},
+		{name: "reordered", current: []string{"A", "B"}, planned: []string{"B", "A"}},
+		{name: "created", planned: []string{"A", "B"}, wantAdded: []string{"A", "B"}},
+		{name: "emptied", current: []string{"A", "B"}, planned: []string{}, wantRemoved: []string{"A", "B"}},
+		{name: "swapped", current: []string{"A", "B", "C"}, planned: []string{"D", "B", "E"}, wantAdded: []string{"D", "E"}, wantRemoved: []string{"A", "C"}},
+		{name: "repeated", current: []string{"A"}, planned: []string{"B", "B", "A"}, wantAdded: []string{"B"}},
+	}
+
+	for _, test := range tests {
+		added, removed := diffIDs(test.current, test.planned)
+		if !reflect.DeepEqual(added, test.wantAdded) || !reflect.DeepEqual(removed, test.wantRemoved) {
+			t.Errorf("%s: diffIDs() = %v, %v, want %v, %v", test.name, added, removed, test.wantAdded, test.wantRemoved)
+		}
+	}
+}
+
+// TestDevResourceUpdatePartialFailure updates a dev against a server where
+// some membership changes fail, and checks that Update reports each failure
+// and records the membership the server holds, so the next plan retries only
+// the failed changes.
+func TestDevResourceUpdatePartialFailure(t *testing.T) {
+	ctx := context.Background()
+	api := fakeserver.New()
+	engineers := map[string]*engineerModel{}
+	for _, name := range []string{"ada", "ben", "carla", "dora"} {
+		engineer := api.AddEngineer(client.Engineer{Name: name, Email: name + "@finches.com"})
+		engineers[name] = newEngineerModel(&engineer)
+	}
+	ada, ben, carla, dora := engineers["ada"], engineers["ben"], engineers["carla"], engineers["dora"]
+	dev := api.AddDev(client.Dev{Name: "dev_finches"}, ada.Id.ValueString(), ben.Id.ValueString())
+	// Removing ben and adding dora fail
+	api.FailMembership(ben.Id.ValueString(), dora.Id.ValueString())
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	r := &devResource{client: client.NewClient(server.URL)}
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	const lastUpdated = "Monday, 02-Jan-06 15:04:05 MST"
+	model := func(members ...*engineerModel) devResourceModel {
+		return devResourceModel{
+			Name:               types.StringValue(dev.Name),
+			Id:                 types.StringValue(dev.Id),
+			Engineers:          members,
+			LastUpdated:        types.StringValue(lastUpdated),
+			Labels:             types.MapNull(types.StringType),
+			LabelsAll:          types.MapNull(types.StringType),
+			DeletionProtection: types.BoolValue(false),
+			ForceDestroy:       types.BoolValue(false),
+		}
+	}
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schema.Schema},
+		State: tfsdk.State{Schema: schema.Schema},
+	}
+	diags := req.Plan.Set(ctx, model(carla, dora))
+	diags.Append(req.State.Set(ctx, model(ada, ben))...)
+	if diags.HasError() {
+		t.Fatalf("building the request: %v", diags)
+	}
+	resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: schema.Schema}}
+
+	r.Update(ctx, req, &resp)
+
+	var summaries []string
+	for _, d := range resp.Diagnostics.Errors() {
+		summaries = append(summaries, d.Summary()+": "+d.Detail())
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected a diagnostic for each failed change, got %q", summaries)
+	}
+	for index, ID := range []string{ben.Id.ValueString(), dora.Id.ValueString()} {
+		if !strings.Contains(summaries[index], "Could not") || !strings.Contains(summaries[index], "Id "+ID+" ") {
+			t.Errorf("expected diagnostic %d to report engineer %s, got %q", index, ID, summaries[index])
+		}
+	}
+
+	var recorded devResourceModel
+	if diags := resp.State.Get(ctx, &recorded); diags.HasError() {
+		t.Fatalf("reading the recorded state: %v", diags)
+	}
+
+	// Planned engineers come first, then those that could not be removed
+	got := modelIDs(recorded.Engineers)
+	if want := modelIDs([]*engineerModel{carla, ben}); !reflect.DeepEqual(got, want) {
+		t.Errorf("recorded engineers = %v, want %v", got, want)
+	}
+	if recorded.LastUpdated.ValueString() != lastUpdated {
+		t.Errorf("expected last_updated to be kept after a failure, got %s", recorded.LastUpdated)
+	}
+
+	var held []string
+	for _, engineer := range api.Devs()[0].Engineers {
+		held = append(held, engineer.Id)
+	}
+	if added, removed := diffIDs(held, got); len(added) != 0 || len(removed) != 0 {
+		t.Errorf("recorded engineers %v, but the server holds %v", got, held)
+	}
+
+	retry, undo := diffEngineers(recorded.Engineers, []*engineerModel{carla, dora})
+	if want := []string{dora.Id.ValueString()}; !reflect.DeepEqual(retry, want) {
+		t.Errorf("next plan adds %v, want %v", retry, want)
+	}
+	if want := []string{ben.Id.ValueString()}; !reflect.DeepEqual(undo, want) {
+		t.Errorf("next plan removes %v, want %v", undo, want)
+	}
+}
+
+// TestDevResourceUpdateMetadata checks that updating the dev's metadata,
+// which replaces the whole dev, sends the membership the resource reconciled
+// rather than whatever the server held.
+func TestDevResourceUpdateMetadata(t *testing.T) {
+	ctx := context.Background()
+	api := fakeserver.New()
+	ada := api.AddEngineer(client.Engineer{Name: "ada", Email: "ada@finches.com", Role: client.RoleSRE})
+	ben := api.AddEngineer(client.Engineer{Name: "ben", Email: "ben@finches.com"})
+	dev := api.AddDev(client.Dev{Name: "dev_finches"}, ada.Id, ben.Id)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	r := &devResource{client: client.NewClient(server.URL)}
+	model := devResourceModel{
+		Name:            types.StringValue("dev_finches_renamed"),
+		Engineers:       []*engineerModel{newEngineerModel(&ada)},
+		Description:     types.StringValue("Finches cohort"),
+		LabelsAll:       types.MapNull(types.StringType),
+		OwnerEngineerId: types.StringValue(ada.Id),
+	}
+
+	var diags diag.Diagnostics
+	updated := r.updateMetadata(ctx, dev.Id, model, &diags)
+	if diags.HasError() || updated == nil {
+		t.Fatalf("updateMetadata() failed: %v", diags)
+	}
+
+	held := api.Devs()[0]
+	if held.Name != "dev_finches_renamed" || held.OwnerEngineerId != ada.Id {
+		t.Errorf("server dev = %+v, want it renamed and owned by %s", held, ada.Id)
+	}
+	if len(held.Engineers) != 1 || !sameEngineer(*held.Engineers[0], ada) {
+		t.Errorf("server engineers = %+v, want only %+v", held.Engineers, ada)
+	}
+}
+
+func TestMatchEngineers(t *testing.T) {
+	sloane := newEngineerModel(&client.Engineer{Id: "G63RN", Name: "sloane", Email: "sloane@finches.com"})
+	ryan := newEngineerModel(&client.Engineer{Id: "UWJVB", Name: "ryan", Email: "ryan@finches.com"})
+	unknown := &engineerModel{Id: types.StringUnknown()}
+
+	got := matchEngineers(append(idModels([]string{"UWJVB", "X1"}), unknown), []*engineerModel{sloane, ryan})
+	if len(got) != 3 {
+		t.Fatalf("matchEngineers() returned %d engineers, want 3", len(got))
+	}
+	if got[0] != ryan {
+		t.Errorf("engineers[0] = %+v, want ryan from state", got[0])
+	}
+	for index, want := range []types.String{types.StringValue("X1"), types.StringUnknown()} {
+		engineer := got[index+1]
+		if !engineer.Id.Equal(want) || !engineer.Name.IsUnknown() || !engineer.Email.IsUnknown() || !engineer.Skills.IsUnknown() {
+			t.Errorf("engineers[%d] = %+v, want %s with unknown attributes", index+1, engineer, want)
+		}
+	}
+}
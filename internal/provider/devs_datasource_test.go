@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+func TestAccDevsDataSourceLabels(t *testing.T) {
+	api := fakeserver.New()
+	sloane := api.AddEngineer(client.Engineer{Id: "G63RN", Name: "sloane", Email: "sloane@finches.com"})
+	api.AddDev(client.Dev{
+		Id: "D1", Name: "dev_finches", Description: "Finches cohort",
+		Labels: map[string]string{"cohort": "2024", "track": "platform"}, OwnerEngineerId: sloane.Id,
+	}, sloane.Id)
+	api.AddDev(client.Dev{Id: "D2", Name: "dev_twins", Labels: map[string]string{"cohort": "2024", "track": "web"}})
+	api.AddDev(client.Dev{Id: "D3", Name: "dev_unlabelled"})
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(filter string) string {
+		return fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host = %q
+}
+
+data "devops-bootcamp_devs" "test" {
+%s
+}
+`, server.URL, filter)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Without a filter every dev is listed
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.#", "3"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.0.description", "Finches cohort"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.0.labels.track", "platform"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.0.owner_engineer_id", "G63RN"),
+					resource.TestCheckNoResourceAttr("data.devops-bootcamp_devs.test", "devs.2.labels.%"),
+				),
+			},
+			// Devs must carry every label in the filter
+			{
+				Config: config(`  labels = { cohort = "2024" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.#", "2"),
+				),
+			},
+			{
+				Config: config(`  labels = { cohort = "2024", track = "web" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.#", "1"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_devs.test", "devs.0.id", "D2"),
+				),
+			},
+		},
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
 )
@@ -35,9 +37,76 @@ type engineerDataSourceModel struct {
 
 // engineerModel maps engineer schema data.
 type engineerModel struct {
-	Name  types.String `tfsdk:"name"`
-	Id    types.String `tfsdk:"id"`
-	Email types.String `tfsdk:"email"`
+	Name   types.String `tfsdk:"name"`
+	Id     types.String `tfsdk:"id"`
+	Email  types.String `tfsdk:"email"`
+	Active types.Bool   `tfsdk:"active"`
+	Role   types.String `tfsdk:"role"`
+	Level  types.String `tfsdk:"level"`
+	Skills types.Set    `tfsdk:"skills"`
+	Labels types.Map    `tfsdk:"labels"`
+}
+
+// newEngineerModel maps an API engineer to engineer schema data.
+func newEngineerModel(engineer *client.Engineer) *engineerModel {
+	return &engineerModel{
+		Name:   types.StringValue(engineer.Name),
+		Id:     types.StringValue(engineer.Id),
+		Email:  types.StringValue(engineer.Email),
+		Active: types.BoolValue(engineer.IsActive()),
+		Role:   optionalString(engineer.Role),
+		Level:  optionalString(engineer.Level),
+		Skills: skillsValue(engineer.Skills),
+		Labels: labelsValue(engineer.Labels),
+	}
+}
+
+// engineer maps engineer schema data back to an API engineer.
+func (m *engineerModel) engineer(ctx context.Context) (client.Engineer, diag.Diagnostics) {
+	engineer := client.Engineer{
+		Name:  m.Name.ValueString(),
+		Id:    m.Id.ValueString(),
+		Email: m.Email.ValueString(),
+		Role:  m.Role.ValueString(),
+		Level: m.Level.ValueString(),
+	}
+	if !m.Active.IsNull() && !m.Active.IsUnknown() {
+		active := m.Active.ValueBool()
+		engineer.Active = &active
+	}
+	diags := m.Skills.ElementsAs(ctx, &engineer.Skills, false)
+	diags.Append(m.Labels.ElementsAs(ctx, &engineer.Labels, false)...)
+
+	return engineer, diags
+}
+
+// optionalString maps an API string the server omits when unset, keeping
+// unset values null.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// skillsValue maps API skills to a set, keeping an engineer without skills
+// null. Skills the API repeats appear once.
+func skillsValue(skills []string) types.Set {
+	if len(skills) == 0 {
+		return types.SetNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		if !seen[skill] {
+			elements = append(elements, types.StringValue(skill))
+			seen[skill] = true
+		}
+	}
+
+	return types.SetValueMust(types.StringType, elements)
 }
 
 // Metadata returns the data source type name.
@@ -66,6 +135,28 @@ func (d *engineerDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 							MarkdownDescription: "Engineer Email required",
 							Required:            true,
 						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the engineer is active. Archived engineers are inactive.",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "Engineer role, one of `dev`, `ops` or `sre`",
+							Computed:            true,
+						},
+						"level": schema.StringAttribute{
+							MarkdownDescription: "Engineer seniority level",
+							Computed:            true,
+						},
+						"skills": schema.SetAttribute{
+							MarkdownDescription: "Engineer skills",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"labels": schema.MapAttribute{
+							MarkdownDescription: "Engineer labels",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -75,9 +166,12 @@ func (d *engineerDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 
 // Read refreshes the Terraform state with the latest data.
 func (d *engineerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer", "Read", "engineer")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state engineerDataSourceModel
 
-	engineers, err := d.client.GetEngineers()
+	engineers, err := d.client.WithContext(ctx).GetEngineers()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read HashiCups Engineer",
@@ -88,13 +182,7 @@ func (d *engineerDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	// Map response body to model
 	for _, engineer := range engineers {
-		engineerState := engineerModel{
-			Name:  types.StringValue(engineer.Name),
-			Id:    types.StringValue(engineer.Id),
-			Email: types.StringValue(engineer.Email),
-		}
-
-		state.Engineer = append(state.Engineer, engineerState)
+		state.Engineer = append(state.Engineer, *newEngineerModel(&engineer))
 	}
 
 	// Set state
@@ -111,15 +199,15 @@ func (d *engineerDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &engineerHandleFunction{}
+
+// NewEngineerHandleFunction is a helper function to simplify the provider implementation.
+func NewEngineerHandleFunction() function.Function {
+	return &engineerHandleFunction{}
+}
+
+// engineerHandleFunction is the function implementation.
+type engineerHandleFunction struct{}
+
+// Metadata returns the function name.
+func (f *engineerHandleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "engineer_handle"
+}
+
+// Definition defines the function signature.
+func (f *engineerHandleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Derive a slug from an engineer name",
+		MarkdownDescription: "Lower-cases an engineer name and replaces every run of characters other than `a-z` and `0-9` with a single `-`, e.g. `Sloane O'Brien` becomes `sloane-o-brien`. Fails when the name has no letters or digits.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Engineer name to derive the handle from.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the handle for the name.
+func (f *engineerHandleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	handle := engineerHandle(name)
+	if handle == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid name: "+name+" has no letters or digits to build a handle from.")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, handle)
+}
+
+// engineerHandle lower-cases name and collapses every run of characters
+// other than a-z and 0-9 into a single separator.
+func engineerHandle(name string) string {
+	var handle strings.Builder
+	pending := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pending && handle.Len() > 0 {
+				handle.WriteByte('-')
+			}
+			handle.WriteRune(r)
+			pending = false
+			continue
+		}
+		pending = true
+	}
+
+	return handle.String()
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEngineerHandleFunction(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		"single word":       {name: "sloane", want: "sloane"},
+		"mixed case":        {name: "Sloane", want: "sloane"},
+		"spaces":            {name: "Sloane Finch", want: "sloane-finch"},
+		"punctuation runs":  {name: "Sloane  O'Brien", want: "sloane-o-brien"},
+		"trimmed":           {name: "  -sloane.finch- ", want: "sloane-finch"},
+		"digits":            {name: "Engineer 42", want: "engineer-42"},
+		"non ascii dropped": {name: "Zoë Ruiz", want: "zo-ruiz"},
+		"no alphanumerics":  {name: " -- ", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := runFunction(t, NewEngineerHandleFunction(), types.StringValue(test.name))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("expected %q, got %s", test.want, got)
+			}
+		})
+	}
+}
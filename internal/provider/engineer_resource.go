@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
-	devops_resource "github.com/liatrio/devops-bootcamp/examples/ch7/devops-resources"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -21,6 +28,14 @@ var (
 	_ resource.Resource                = &engineerResource{}
 	_ resource.ResourceWithConfigure   = &engineerResource{}
 	_ resource.ResourceWithImportState = &engineerResource{}
+	_ resource.ResourceWithModifyPlan  = &engineerResource{}
+)
+
+// What happens to an engineer when its resource is destroyed.
+const (
+	onDestroyDelete  = "delete"
+	onDestroyArchive = "archive"
+	onDestroyAbandon = "abandon"
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -30,7 +45,8 @@ func NewEngineerResource() resource.Resource {
 
 // engineerResource is the resource implementation.
 type engineerResource struct {
-	client *client.Client
+	client        *client.Client
+	defaultLabels map[string]string
 }
 
 // engineerResourceModel maps engineer schema data.
@@ -38,9 +54,43 @@ type engineerResourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	Id          types.String `tfsdk:"id"`
 	Email       types.String `tfsdk:"email"`
+	Active      types.Bool   `tfsdk:"active"`
+	Role        types.String `tfsdk:"role"`
+	Level       types.String `tfsdk:"level"`
+	Skills      types.Set    `tfsdk:"skills"`
+	Labels      types.Map    `tfsdk:"labels"`
+	LabelsAll   types.Map    `tfsdk:"labels_all"`
+	OnDestroy   types.String `tfsdk:"on_destroy"`
 	LastUpdated types.String `tfsdk:"last_updated"`
 }
 
+// engineer generates an API request body from engineer schema data.
+func (m engineerResourceModel) engineer(ctx context.Context) (client.Engineer, diag.Diagnostics) {
+	engineer := client.Engineer{
+		Name:  m.Name.ValueString(),
+		Id:    m.Id.ValueString(),
+		Email: m.Email.ValueString(),
+		Role:  m.Role.ValueString(),
+		Level: m.Level.ValueString(),
+	}
+	diags := m.Skills.ElementsAs(ctx, &engineer.Skills, false)
+	diags.Append(m.LabelsAll.ElementsAs(ctx, &engineer.Labels, false)...)
+
+	return engineer, diags
+}
+
+// setEngineer maps an API engineer to engineer schema data.
+func (m *engineerResourceModel) setEngineer(engineer *client.Engineer) {
+	m.Name = types.StringValue(engineer.Name)
+	m.Id = types.StringValue(engineer.Id)
+	m.Email = types.StringValue(engineer.Email)
+	m.Active = types.BoolValue(engineer.IsActive())
+	m.Role = optionalString(engineer.Role)
+	m.Level = optionalString(engineer.Level)
+	m.Skills = skillsValue(engineer.Skills)
+	m.LabelsAll = labelsValue(engineer.Labels)
+}
+
 // Metadata returns the resource type name.
 func (r *engineerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_engineer_resource"
@@ -62,6 +112,61 @@ func (r *engineerResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"email": schema.StringAttribute{
 				Required: true, // Email must be provided by the user
 			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the engineer is active. Engineers archived with `on_destroy = \"archive\"` are inactive.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Role of the engineer, one of `dev`, `ops` or `sre`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.Roles...),
+				},
+			},
+			"level": schema.StringAttribute{
+				MarkdownDescription: "Seniority of the engineer, such as `junior` or `senior`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"skills": schema.SetAttribute{
+				MarkdownDescription: "Skills of the engineer.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Labels of the engineer. They are merged over the provider `default_labels`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"labels_all": schema.MapAttribute{
+				MarkdownDescription: "Labels of the engineer, including the provider `default_labels`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What destroying the resource does to the engineer. " +
+					"`delete` deletes it, which also removes it from every dev. " +
+					"`archive` keeps it and its dev memberships but marks it inactive. " +
+					"`abandon` only removes it from the Terraform state. Defaults to `delete`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyDelete, onDestroyArchive, onDestroyAbandon),
+				},
+			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
@@ -69,8 +174,42 @@ func (r *engineerResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	}
 }
 
+// ModifyPlan merges the provider default labels into labels_all, fails any
+// change while the provider is read only, and fails the plan when an
+// engineer would be archived on destroy but the server does not support
+// archiving.
+func (r *engineerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		planLabelsAll(ctx, r.defaultLabels, req, resp)
+	}
+	if checkReadOnly(r.client, "engineer", req, resp) {
+		return
+	}
+	if r.client == nil || !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var onDestroy types.String
+	diags := req.State.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if onDestroy.ValueString() == onDestroyArchive && !r.client.Supports(client.FeatureArchive) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("on_destroy"),
+			"Archiving not supported",
+			archiveUnsupported(r.client),
+		)
+	}
+}
+
 // Create a new engineer resource.
 func (r *engineerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_resource", "Create", "engineer")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	log.Printf("Debug: Create request: %v", req)
 	// Retrieve values from plan
 	var plan engineerResourceModel
@@ -81,16 +220,17 @@ func (r *engineerResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	var engineerObject devops_resource.Engineer
-	engineerObject.Name = plan.Name.ValueString()
-	engineerObject.Id = plan.Id.ValueString()
-	engineerObject.Email = plan.Email.ValueString()
+	engineerObject, diags := plan.engineer(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Print to standard logger; this will appear if TF_LOG=DEBUG is set
 	log.Printf("Debug: Engineer Object: %#v", engineerObject)
 
 	// Create new engineer
-	engineer, err := r.client.CreateEngineer(engineerObject)
+	engineer, err := r.client.WithContext(ctx).CreateEngineer(engineerObject)
 	if err != nil {
 		log.Printf("Error: %v", err)
 		resp.Diagnostics.AddError(
@@ -101,9 +241,8 @@ func (r *engineerResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	plan.Name = types.StringValue(engineer.Name)
-	plan.Id = types.StringValue(engineer.Id)
-	plan.Email = types.StringValue(engineer.Email)
+	plan.setEngineer(engineer)
+	setSpanEntityID(span, engineer.Id)
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -118,6 +257,9 @@ func (r *engineerResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read resource information.
 func (r *engineerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_resource", "Read", "engineer")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	log.Printf("Debug: Read request: %v", req)
 	// Get current state
 	var state engineerResourceModel
@@ -128,8 +270,10 @@ func (r *engineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	setSpanEntityID(span, state.Id.ValueString())
+
 	// Get refreshed engineer value from HashiCups
-	engineer, err := r.client.GetEngineer(state.Id.ValueString())
+	engineer, err := r.client.WithContext(ctx).GetEngineer(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error sending get request to devops-bootcamp api",
@@ -139,9 +283,13 @@ func (r *engineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	state.Name = types.StringValue(engineer.Name)
-	state.Id = types.StringValue(engineer.Id)
-	state.Email = types.StringValue(engineer.Email)
+	state.setEngineer(engineer)
+	state.Labels = configuredLabels(engineer.Labels, state.Labels, r.defaultLabels)
+
+	// Imported engineers have no on_destroy yet
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue(onDestroyDelete)
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -153,6 +301,9 @@ func (r *engineerResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *engineerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_resource", "Update", "engineer")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	log.Printf("Debug: Update request: %v", req)
 	// Retrieve values from plan
 	var plan engineerResourceModel
@@ -163,16 +314,17 @@ func (r *engineerResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Generate API request body from plan
-	var engineerObject devops_resource.Engineer
-	engineerObject.Name = plan.Name.ValueString()
-	engineerObject.Id = plan.Id.ValueString()
-	engineerObject.Email = plan.Email.ValueString()
+	engineerObject, diags := plan.engineer(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Print to standard logger; this will appear if TF_LOG=DEBUG is set
 	log.Printf("Debug: Engineer Object: %#v", engineerObject)
 
 	// Update existing engineer
-	engineer, err := r.client.UpdateEngineer(engineerObject)
+	engineer, err := r.client.WithContext(ctx).UpdateEngineer(engineerObject)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating engineer",
@@ -182,9 +334,8 @@ func (r *engineerResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	plan.Name = types.StringValue(engineer.Name)
-	plan.Id = types.StringValue(engineer.Id)
-	plan.Email = types.StringValue(engineer.Email)
+	plan.setEngineer(engineer)
+	setSpanEntityID(span, engineer.Id)
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -198,6 +349,9 @@ func (r *engineerResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *engineerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_resource", "Delete", "engineer")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	// Retrieve values from state
 	var state engineerResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -206,14 +360,47 @@ func (r *engineerResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	// Delete existing order
-	err := r.client.DeleteEngineer(state.Id.String())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting engineer",
-			"Could not delete order, unexpected error: "+err.Error(),
-		)
-		return
+	setSpanEntityID(span, state.Id.ValueString())
+	switch state.OnDestroy.ValueString() {
+	case onDestroyAbandon:
+		// Forget the engineer, leaving it untouched on the server
+		log.Printf("Debug: Abandoning engineer %s", state.Id.ValueString())
+	case onDestroyArchive:
+		if !r.client.Supports(client.FeatureArchive) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("on_destroy"),
+				"Archiving not supported",
+				archiveUnsupported(r.client),
+			)
+			return
+		}
+
+		// Mark the engineer inactive, keeping its dev memberships
+		engineer, diags := state.engineer(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		active := false
+		engineer.Active = &active
+		_, err := r.client.WithContext(ctx).UpdateEngineer(engineer)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error archiving engineer",
+				"Could not archive engineer Id "+state.Id.ValueString()+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	default:
+		// Delete existing order
+		err := r.client.WithContext(ctx).DeleteEngineer(state.Id.String())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting engineer",
+				"Could not delete order, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
 }
 
@@ -223,21 +410,82 @@ func (r *engineerResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.defaultLabels = data.DefaultLabels
 }
 
+// ImportState imports an engineer by ID, or by "email:<email>" or
+// "name:<name>" so import blocks can be written without knowing the
+// server-generated ID.
 func (r *engineerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_resource", "ImportState", "engineer")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	attribute, value, found := strings.Cut(req.ID, ":")
+	if !found || (attribute != "email" && attribute != "name") {
+		// Retrieve import ID and save to id attribute
+		setSpanEntityID(span, req.ID)
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	engineers, err := r.client.WithContext(ctx).GetEngineers()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error sending get request to devops-bootcamp api",
+			"Could not read engineers: "+err.Error(),
+		)
+		return
+	}
+
+	engineer, err := findEngineer(engineers, attribute, value)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import engineer", err.Error())
+		return
+	}
+
+	setSpanEntityID(span, engineer.Id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), engineer.Id)...)
+}
+
+// archiveUnsupported explains that the server cannot archive engineers.
+func archiveUnsupported(c *client.Client) string {
+	return fmt.Sprintf("The DevOps Bootcamp API version %s does not support archiving engineers. "+
+		"Set on_destroy to \"delete\" or \"abandon\" before destroying the engineer.", c.Server.Version)
+}
+
+// findEngineer finds the only engineer whose email or name matches value.
+// Emails are compared the way the roster normalizes them.
+func findEngineer(engineers []client.Engineer, attribute, value string) (*client.Engineer, error) {
+	var matched []*client.Engineer
+	for index, engineer := range engineers {
+		if (attribute == "email" && normalizeEmail(engineer.Email) == normalizeEmail(value)) ||
+			(attribute == "name" && engineer.Name == value) {
+			matched = append(matched, &engineers[index])
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no engineer has the %s %q", attribute, value)
+	case 1:
+		return matched[0], nil
+	default:
+		IDs := make([]string, len(matched))
+		for index, engineer := range matched {
+			IDs[index] = engineer.Id
+		}
+		return nil, fmt.Errorf("%d engineers have the %s %q, import one of them by ID instead: %s", len(matched), attribute, value, strings.Join(IDs, ", "))
+	}
 }
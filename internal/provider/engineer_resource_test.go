@@ -1,12 +1,20 @@
 package provider
 
 import (
+	"fmt"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
 )
 
 func TestAccEngineerResource(t *testing.T) {
+	useCassette(t)
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -22,7 +30,9 @@ resource "devops-bootcamp_engineer_resource" "test" {
 					// Verify first engineer resource has required attributes filled.
 					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "name", "test"),
 					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "email", "test@test.com"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "on_destroy", "delete"),
 					// Verify first engineer resource has Computed attributes filled.
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "active", "true"),
 					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer_resource.test", "id"),
 					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer_resource.test", "last_updated"),
 				),
@@ -36,6 +46,14 @@ resource "devops-bootcamp_engineer_resource" "test" {
 				// API, therefore there is no value for it during import.
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			// ImportState testing by email
+			{
+				ResourceName:            "devops-bootcamp_engineer_resource.test",
+				ImportState:             true,
+				ImportStateId:           "email:TEST@test.com",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
@@ -57,3 +75,143 @@ resource "devops-bootcamp_engineer_resource" "test" {
 		},
 	})
 }
+
+func TestAccEngineerResourceArchive(t *testing.T) {
+	api := fakeserver.New()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host = %q
+}
+
+resource "devops-bootcamp_engineer_resource" "test" {
+  name       = "archived"
+  email      = "archived@test.com"
+  role       = "ops"
+  on_destroy = "archive"
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "on_destroy", "archive"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "active", "true"),
+				),
+			},
+		},
+		// Archived engineers stay on the server, marked inactive
+		CheckDestroy: func(_ *terraform.State) error {
+			engineers := api.Engineers()
+			if len(engineers) != 1 {
+				return fmt.Errorf("expected the archived engineer to remain, got %d engineers", len(engineers))
+			}
+			if engineers[0].IsActive() {
+				return fmt.Errorf("expected engineer %s to be inactive", engineers[0].Id)
+			}
+			if engineers[0].Role != client.RoleOps {
+				return fmt.Errorf("expected engineer %s to keep role ops, got %q", engineers[0].Id, engineers[0].Role)
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccEngineerResourceProfile(t *testing.T) {
+	server := httptest.NewServer(fakeserver.New())
+	defer server.Close()
+
+	config := func(profile string) string {
+		return fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host = %q
+}
+
+resource "devops-bootcamp_engineer_resource" "test" {
+  name  = "sloane"
+  email = "sloane@finches.com"
+%s
+}
+`, server.URL, profile)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown roles are rejected at plan time
+			{
+				Config:      config(`  role = "manager"`),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			// Create and Read testing
+			{
+				Config: config(`
+  role   = "sre"
+  level  = "senior"
+  skills = ["terraform", "go"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "role", "sre"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "level", "senior"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "skills.#", "2"),
+					resource.TestCheckTypeSetElemAttr("devops-bootcamp_engineer_resource.test", "skills.*", "go"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "devops-bootcamp_engineer_resource.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing, clearing the level and skills
+			{
+				Config: config(`  role = "dev"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.test", "role", "dev"),
+					resource.TestCheckNoResourceAttr("devops-bootcamp_engineer_resource.test", "level"),
+					resource.TestCheckNoResourceAttr("devops-bootcamp_engineer_resource.test", "skills.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestFindEngineer(t *testing.T) {
+	engineers := []client.Engineer{
+		{Id: "G63RN", Name: "sloane", Email: "sloane@finches.com"},
+		{Id: "UWJVB", Name: "ryan", Email: "ryan@finches.com"},
+		{Id: "X1", Name: "ryan", Email: "ryan2@finches.com"},
+	}
+
+	tests := []struct {
+		attribute, value string
+		wantID           string
+		wantErr          string
+	}{
+		{attribute: "email", value: " Sloane@Finches.com", wantID: "G63RN"},
+		{attribute: "name", value: "sloane", wantID: "G63RN"},
+		{attribute: "name", value: "ryan", wantErr: `2 engineers have the name "ryan", import one of them by ID instead: UWJVB, X1`},
+		{attribute: "email", value: "nobody@finches.com", wantErr: `no engineer has the email "nobody@finches.com"`},
+	}
+
+	for _, test := range tests {
+		engineer, err := findEngineer(engineers, test.attribute, test.value)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("findEngineer(%s, %q) error = %v, want %q", test.attribute, test.value, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("findEngineer(%s, %q) unexpected error: %s", test.attribute, test.value, err)
+			continue
+		}
+		if engineer.Id != test.wantID {
+			t.Errorf("findEngineer(%s, %q) = %s, want %s", test.attribute, test.value, engineer.Id, test.wantID)
+		}
+	}
+}
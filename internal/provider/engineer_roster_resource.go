@@ -0,0 +1,609 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &engineerRosterResource{}
+	_ resource.ResourceWithConfigure      = &engineerRosterResource{}
+	_ resource.ResourceWithValidateConfig = &engineerRosterResource{}
+	_ resource.ResourceWithModifyPlan     = &engineerRosterResource{}
+)
+
+// Roster member statuses recorded by the last apply.
+const (
+	rosterStatusCreated   = "created"
+	rosterStatusUpdated   = "updated"
+	rosterStatusUnchanged = "unchanged"
+)
+
+// NewEngineerRosterResource is a helper function to simplify the provider implementation.
+func NewEngineerRosterResource() resource.Resource {
+	return &engineerRosterResource{}
+}
+
+// engineerRosterResource is the resource implementation.
+type engineerRosterResource struct {
+	client        *client.Client
+	defaultLabels map[string]string
+}
+
+// engineerRosterResourceModel maps engineer roster schema data.
+type engineerRosterResourceModel struct {
+	Id          types.String                 `tfsdk:"id"`
+	Engineers   []rosterEngineerModel        `tfsdk:"engineers"`
+	Labels      types.Map                    `tfsdk:"labels"`
+	LabelsAll   types.Map                    `tfsdk:"labels_all"`
+	Members     map[string]rosterMemberModel `tfsdk:"members"`
+	LastUpdated types.String                 `tfsdk:"last_updated"`
+}
+
+// rosterEngineerModel maps a roster row.
+type rosterEngineerModel struct {
+	Name  types.String `tfsdk:"name"`
+	Email types.String `tfsdk:"email"`
+}
+
+// rosterMemberModel maps the engineer managed for a roster row.
+type rosterMemberModel struct {
+	Id     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Email  types.String `tfsdk:"email"`
+	Status types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (r *engineerRosterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_engineer_roster"
+}
+
+// Schema defines the schema for the resource.
+func (r *engineerRosterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a whole cohort of engineers from a single list, such as the result of `csvdecode` or `yamldecode`. " +
+			"Rows are matched to engineers by email, so renaming a row updates the engineer in place and removing a row deletes it. " +
+			"The API has no bulk endpoint, so rows are reconciled with concurrent requests bounded by the provider `parallelism`. " +
+			"The roster only manages the name, email and roster labels of its engineers and keeps anything else set on them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"engineers": schema.ListNestedAttribute{
+				MarkdownDescription: "Engineers in the roster. Emails must be unique, ignoring case.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"email": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Labels set on every engineer in the roster. They are merged over the provider `default_labels`, " +
+					"and other labels on the engineers are kept.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+			"labels_all": schema.MapAttribute{
+				MarkdownDescription: "Labels set on every engineer in the roster, including the provider `default_labels`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"members": schema.MapNestedAttribute{
+				MarkdownDescription: "Engineers managed by the roster, keyed by lower-cased email.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"email": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "What the last apply did with the row: `created`, `updated` or `unchanged`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures every roster row has a distinct email.
+func (r *engineerRosterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config engineerRosterResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]int, len(config.Engineers))
+	for index, engineer := range config.Engineers {
+		if engineer.Email.IsUnknown() || engineer.Email.IsNull() {
+			continue
+		}
+		key := normalizeEmail(engineer.Email.ValueString())
+		if first, ok := seen[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("engineers").AtListIndex(index).AtName("email"),
+				"Duplicate roster email",
+				fmt.Sprintf("The email %q is already used by engineers[%d].", engineer.Email.ValueString(), first),
+			)
+			continue
+		}
+		seen[key] = index
+	}
+}
+
+// ModifyPlan merges the provider default labels into labels_all and fails
+// any change to the roster while the provider is read only.
+func (r *engineerRosterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		planLabelsAll(ctx, r.defaultLabels, req, resp)
+	}
+
+	checkReadOnly(r.client, "engineer roster", req, resp)
+}
+
+// Create creates every engineer in the roster.
+func (r *engineerRosterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_roster", "Create", "engineer_roster")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	log.Printf("Debug: Create request: %v", req)
+	// Retrieve the rows and labels from plan, members is unknown until applied
+	var plan engineerRosterResourceModel
+	resp.Diagnostics.Append(plan.getPlanned(ctx, req.Plan)...)
+	if resp.Diagnostics.HasError() {
+		log.Printf("Error: %v", resp.Diagnostics)
+		return
+	}
+
+	var labels map[string]string
+	diags := plan.LabelsAll.ElementsAs(ctx, &labels, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members := map[string]rosterMemberModel{}
+	r.createMembers(ctx, plan.Engineers, labels, members, &resp.Diagnostics)
+
+	plan.Id = types.StringValue("roster-" + strconv.FormatInt(time.Now().UnixNano(), 36))
+	plan.Engineers = rosterRows(plan.Engineers, members)
+	plan.Members = members
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to the engineers that were created, even on a partial failure
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		log.Printf("Error: %v", resp.Diagnostics)
+		return
+	}
+}
+
+// Read refreshes every roster member from a single engineers listing.
+func (r *engineerRosterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_roster", "Read", "engineer_roster")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	log.Printf("Debug: Read request: %v", req)
+	// Get current state
+	var state engineerRosterResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		log.Printf("Error: %v", resp.Diagnostics)
+		return
+	}
+
+	engineers, err := r.client.WithContext(ctx).GetEngineers()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error sending get request to devops-bootcamp api",
+			"Could not read engineers: "+err.Error(),
+		)
+		return
+	}
+
+	byID := make(map[string]client.Engineer, len(engineers))
+	for _, engineer := range engineers {
+		byID[engineer.Id] = engineer
+	}
+
+	// Members deleted outside of Terraform drop out of the roster, so the
+	// next plan creates them again.
+	for key, member := range state.Members {
+		engineer, ok := byID[member.Id.ValueString()]
+		if !ok {
+			delete(state.Members, key)
+			continue
+		}
+		member.Name = types.StringValue(engineer.Name)
+		member.Email = types.StringValue(engineer.Email)
+		state.Members[key] = member
+	}
+	state.Engineers = rosterRows(state.Engineers, state.Members)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update reconciles the roster, keyed by email: new rows are created,
+// changed rows are updated and removed rows are deleted.
+func (r *engineerRosterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_roster", "Update", "engineer_roster")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	log.Printf("Debug: Update request: %v", req)
+	// Retrieve the rows and labels from plan, members is unknown until
+	// applied, and the current state
+	var plan, state engineerRosterResourceModel
+	resp.Diagnostics.Append(plan.getPlanned(ctx, req.Plan)...)
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		log.Printf("Error: %v", resp.Diagnostics)
+		return
+	}
+
+	var labels, prior map[string]string
+	resp.Diagnostics.Append(plan.LabelsAll.ElementsAs(ctx, &labels, false)...)
+	resp.Diagnostics.Append(state.LabelsAll.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A change to the labels updates every member
+	relabel := !plan.LabelsAll.Equal(state.LabelsAll)
+	members, toCreate, toUpdate, toDelete := diffRoster(plan.Engineers, state.Members, relabel)
+
+	r.deleteMembers(ctx, toDelete, members, &resp.Diagnostics)
+	r.updateMembers(ctx, toUpdate, labels, removedLabels(prior, labels), members, &resp.Diagnostics)
+	r.createMembers(ctx, toCreate, labels, members, &resp.Diagnostics)
+
+	plan.Id = state.Id
+	plan.Engineers = rosterRows(plan.Engineers, members)
+	plan.Members = members
+	if resp.Diagnostics.HasError() {
+		plan.LastUpdated = state.LastUpdated
+	} else {
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	}
+
+	// Set state to the roster as it now exists on the server
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		log.Printf("Error: %v", resp.Diagnostics)
+		return
+	}
+}
+
+// Delete deletes every engineer in the roster.
+func (r *engineerRosterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "devops-bootcamp_engineer_roster", "Delete", "engineer_roster")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	// Retrieve values from state
+	var state engineerRosterResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := make([]string, 0, len(state.Members))
+	for key := range state.Members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	r.deleteMembers(ctx, keys, state.Members, &resp.Diagnostics)
+	if !resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the engineers that could not be deleted so a retry only targets them
+	state.Engineers = rosterRows(state.Engineers, state.Members)
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *engineerRosterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.defaultLabels = data.DefaultLabels
+}
+
+// getPlanned reads the rows and labels from plan. Members is unknown until
+// applied, so the whole plan cannot be read into the model.
+func (m *engineerRosterResourceModel) getPlanned(ctx context.Context, plan tfsdk.Plan) diag.Diagnostics {
+	diags := plan.GetAttribute(ctx, path.Root("engineers"), &m.Engineers)
+	diags.Append(plan.GetAttribute(ctx, path.Root("labels"), &m.Labels)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("labels_all"), &m.LabelsAll)...)
+
+	return diags
+}
+
+// createMembers creates the engineers for the rows with labels and records
+// them in members.
+func (r *engineerRosterResource) createMembers(ctx context.Context, rows []rosterEngineerModel, labels map[string]string, members map[string]rosterMemberModel, diags *diag.Diagnostics) {
+	engineers := make([]client.Engineer, len(rows))
+	for index, row := range rows {
+		engineers[index] = client.Engineer{
+			Name:   row.Name.ValueString(),
+			Email:  row.Email.ValueString(),
+			Labels: rosterLabels(nil, labels, nil),
+		}
+	}
+
+	created, errs := r.client.WithContext(ctx).CreateEngineers(engineers)
+	for index, row := range rows {
+		if errs[index] != nil {
+			diags.AddAttributeError(
+				path.Root("engineers"),
+				"Error creating engineer",
+				"Could not create engineer "+row.Email.ValueString()+": "+errs[index].Error(),
+			)
+			continue
+		}
+		members[normalizeEmail(row.Email.ValueString())] = rosterMember(created[index], rosterStatusCreated)
+	}
+}
+
+// updateMembers updates the engineers for the rows, which must already be in
+// members. An update replaces the whole engineer, so each one is read first
+// and only its name, email and the roster labels are changed: labels in
+// removed are dropped and labels are set over the rest.
+func (r *engineerRosterResource) updateMembers(ctx context.Context, rows []rosterEngineerModel, labels map[string]string, removed []string, members map[string]rosterMemberModel, diags *diag.Diagnostics) {
+	if len(rows) == 0 {
+		return
+	}
+
+	current, err := r.client.WithContext(ctx).GetEngineers()
+	if err != nil {
+		diags.AddError(
+			"Error sending get request to devops-bootcamp api",
+			"Could not read engineers: "+err.Error(),
+		)
+		return
+	}
+
+	byID := make(map[string]client.Engineer, len(current))
+	for _, engineer := range current {
+		byID[engineer.Id] = engineer
+	}
+
+	engineers := make([]client.Engineer, len(rows))
+	for index, row := range rows {
+		id := members[normalizeEmail(row.Email.ValueString())].Id.ValueString()
+		engineer := byID[id]
+		engineer.Id = id
+		engineer.Name = row.Name.ValueString()
+		engineer.Email = row.Email.ValueString()
+		engineer.Labels = rosterLabels(engineer.Labels, labels, removed)
+		engineers[index] = engineer
+	}
+
+	updated, errs := r.client.WithContext(ctx).UpdateEngineers(engineers)
+	for index, row := range rows {
+		if errs[index] != nil {
+			diags.AddAttributeError(
+				path.Root("engineers"),
+				"Error updating engineer",
+				"Could not update engineer "+row.Email.ValueString()+": "+errs[index].Error(),
+			)
+			continue
+		}
+		members[normalizeEmail(row.Email.ValueString())] = rosterMember(updated[index], rosterStatusUpdated)
+	}
+}
+
+// deleteMembers deletes the engineers for the given member keys and removes
+// them from members.
+func (r *engineerRosterResource) deleteMembers(ctx context.Context, keys []string, members map[string]rosterMemberModel, diags *diag.Diagnostics) {
+	IDs := make([]string, len(keys))
+	for index, key := range keys {
+		IDs[index] = members[key].Id.ValueString()
+	}
+
+	errs := r.client.WithContext(ctx).DeleteEngineers(IDs)
+	for index, key := range keys {
+		if errs[index] != nil {
+			diags.AddAttributeError(
+				path.Root("engineers"),
+				"Error deleting engineer",
+				"Could not delete engineer "+members[key].Email.ValueString()+": "+errs[index].Error(),
+			)
+			continue
+		}
+		delete(members, key)
+	}
+}
+
+// rosterMember maps an API engineer to a roster member.
+func rosterMember(engineer *client.Engineer, status string) rosterMemberModel {
+	return rosterMemberModel{
+		Id:     types.StringValue(engineer.Id),
+		Name:   types.StringValue(engineer.Name),
+		Email:  types.StringValue(engineer.Email),
+		Status: types.StringValue(status),
+	}
+}
+
+// diffRoster splits the planned rows into the rows to create and the rows to
+// update, and returns the keys of the current members to delete. Every
+// current row is updated when relabel is set. The members it returns hold
+// every current member, with unchanged ones marked as such.
+func diffRoster(rows []rosterEngineerModel, current map[string]rosterMemberModel, relabel bool) (members map[string]rosterMemberModel, toCreate, toUpdate []rosterEngineerModel, toDelete []string) {
+	members = make(map[string]rosterMemberModel, len(current))
+	planned := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		key := normalizeEmail(row.Email.ValueString())
+		planned[key] = true
+
+		member, ok := current[key]
+		switch {
+		case !ok:
+			toCreate = append(toCreate, row)
+		case relabel || !member.matches(row):
+			toUpdate = append(toUpdate, row)
+			members[key] = member
+		default:
+			member.Status = types.StringValue(rosterStatusUnchanged)
+			members[key] = member
+		}
+	}
+
+	for key, member := range current {
+		if !planned[key] {
+			toDelete = append(toDelete, key)
+			members[key] = member
+		}
+	}
+	sort.Strings(toDelete)
+
+	return members, toCreate, toUpdate, toDelete
+}
+
+// matches reports whether the member is the engineer the row describes. The
+// server may normalize emails, so they are compared in their canonical form.
+func (m rosterMemberModel) matches(row rosterEngineerModel) bool {
+	return m.Name.ValueString() == row.Name.ValueString() &&
+		normalizeEmail(m.Email.ValueString()) == normalizeEmail(row.Email.ValueString())
+}
+
+// rosterRows returns the rows describing members, ordered like rows and
+// followed by any remaining members sorted by key. Rows matching their member
+// are kept as written, so an email the server normalized does not show up as
+// a change; other rows take the member's values.
+func rosterRows(rows []rosterEngineerModel, members map[string]rosterMemberModel) []rosterEngineerModel {
+	result := []rosterEngineerModel{}
+	seen := make(map[string]bool, len(members))
+
+	for _, row := range rows {
+		key := normalizeEmail(row.Email.ValueString())
+		member, ok := members[key]
+		if !ok || seen[key] {
+			continue
+		}
+		if member.matches(row) {
+			result = append(result, rosterEngineerModel{Name: row.Name, Email: row.Email})
+		} else {
+			result = append(result, rosterEngineerModel{Name: member.Name, Email: member.Email})
+		}
+		seen[key] = true
+	}
+
+	var remaining []string
+	for key := range members {
+		if !seen[key] {
+			remaining = append(remaining, key)
+		}
+	}
+	sort.Strings(remaining)
+	for _, key := range remaining {
+		result = append(result, rosterEngineerModel{Name: members[key].Name, Email: members[key].Email})
+	}
+
+	return result
+}
+
+// rosterLabels returns the labels of an engineer in the roster: its current
+// labels without the removed ones, with labels set over them. It returns nil
+// when there are none.
+func rosterLabels(current, labels map[string]string, removed []string) map[string]string {
+	result := make(map[string]string, len(current)+len(labels))
+	for key, value := range current {
+		result[key] = value
+	}
+	for _, key := range removed {
+		delete(result, key)
+	}
+	for key, value := range labels {
+		result[key] = value
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// removedLabels returns the keys in prior that are not in labels, sorted.
+func removedLabels(prior, labels map[string]string) []string {
+	var removed []string
+	for key := range prior {
+		if _, ok := labels[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+
+	return removed
+}
+
+// normalizeEmail returns the canonical form of an email used to match roster rows.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
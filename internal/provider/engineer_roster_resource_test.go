@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+func TestAccEngineerRosterResource(t *testing.T) {
+	useCassette(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer_roster" "test" {
+	engineers = csvdecode(<<-EOT
+		name,email
+		test.roster.a,test.roster.a@test.com
+		test.roster.b,test.roster.b@test.com
+	EOT
+	)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "engineers.#", "2"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.%", "2"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer_roster.test", "members.test.roster.a@test.com.id"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.test.roster.a@test.com.status", "created"),
+					resource.TestCheckResourceAttrSet("devops-bootcamp_engineer_roster.test", "last_updated"),
+				),
+			},
+			// Update and Read testing: rename one row, drop one and add one
+			{
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer_roster" "test" {
+	engineers = [
+		{ name = "test.roster.a.edit", email = "test.roster.a@test.com" },
+		{ name = "test.roster.c", email = "test.roster.c@test.com" },
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.%", "2"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.test.roster.a@test.com.name", "test.roster.a.edit"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.test.roster.a@test.com.status", "updated"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.test.roster.c@test.com.status", "created"),
+					resource.TestCheckNoResourceAttr("devops-bootcamp_engineer_roster.test", "members.test.roster.b@test.com.id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEngineerRosterResourceKeepsEngineers(t *testing.T) {
+	api := fakeserver.New()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(name, labels string) string {
+		return fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host           = %q
+  default_labels = { workspace = "finches" }
+}
+
+resource "devops-bootcamp_engineer_roster" "test" {
+  engineers = [
+    { name = %q, email = "ada@finches.com" },
+    { name = "ben", email = "ben@finches.com" },
+  ]
+  labels = %s
+}
+`, server.URL, name, labels)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the roster and default labels
+			{
+				Config: config("ada", `{ cohort = "2025" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "labels_all.%", "2"),
+					checkServerEngineer(api, "ben@finches.com", "ben", "", map[string]string{"cohort": "2025", "workspace": "finches"}),
+				),
+			},
+			// Renaming keeps the role and labels set outside of the roster
+			{
+				PreConfig: func() {
+					for _, engineer := range api.Engineers() {
+						if engineer.Email != "ada@finches.com" {
+							continue
+						}
+						engineer.Role = client.RoleOps
+						labels := map[string]string{"mentor": "sloane"}
+						for key, value := range engineer.Labels {
+							labels[key] = value
+						}
+						engineer.Labels = labels
+						if _, err := client.NewClient(server.URL).UpdateEngineer(engineer); err != nil {
+							t.Fatalf("seeding role and labels: %s", err)
+						}
+					}
+				},
+				Config: config("ada lovelace", `{ cohort = "2025" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.ada@finches.com.status", "updated"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.ben@finches.com.status", "unchanged"),
+					checkServerEngineer(api, "ada@finches.com", "ada lovelace", client.RoleOps,
+						map[string]string{"cohort": "2025", "mentor": "sloane", "workspace": "finches"}),
+				),
+			},
+			// Changing the roster labels updates every engineer and only
+			// drops the labels the roster set
+			{
+				Config: config("ada lovelace", `{ track = "platform" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_roster.test", "members.ben@finches.com.status", "updated"),
+					checkServerEngineer(api, "ada@finches.com", "ada lovelace", client.RoleOps,
+						map[string]string{"mentor": "sloane", "track": "platform", "workspace": "finches"}),
+					checkServerEngineer(api, "ben@finches.com", "ben", "", map[string]string{"track": "platform", "workspace": "finches"}),
+				),
+			},
+		},
+	})
+}
+
+// checkServerEngineer checks the name, role and labels the server holds for
+// the engineer with email.
+func checkServerEngineer(api *fakeserver.Server, email, name, role string, labels map[string]string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		for _, engineer := range api.Engineers() {
+			if engineer.Email != email {
+				continue
+			}
+			if engineer.Name != name || engineer.Role != role {
+				return fmt.Errorf("expected %s to be %q with role %q, got %q with role %q", email, name, role, engineer.Name, engineer.Role)
+			}
+			if got := fmt.Sprint(engineer.Labels); got != fmt.Sprint(labels) {
+				return fmt.Errorf("expected %s to have labels %s, got %s", email, fmt.Sprint(labels), got)
+			}
+			return nil
+		}
+		return fmt.Errorf("expected the server to have an engineer %s", email)
+	}
+}
+
+func rosterRow(name, email string) rosterEngineerModel {
+	return rosterEngineerModel{Name: types.StringValue(name), Email: types.StringValue(email)}
+}
+
+func rosterTestMember(id, name, email string) rosterMemberModel {
+	return rosterMemberModel{
+		Id:     types.StringValue(id),
+		Name:   types.StringValue(name),
+		Email:  types.StringValue(email),
+		Status: types.StringValue(rosterStatusCreated),
+	}
+}
+
+func TestRosterRows(t *testing.T) {
+	members := map[string]rosterMemberModel{
+		"ada@finches.com":    rosterTestMember("E1", "ada", "ada@finches.com"),
+		"ben@finches.com":    rosterTestMember("E2", "ben", "ben@finches.com"),
+		"carla@finches.com":  rosterTestMember("E3", "carla", "carla@finches.com"),
+		"arthur@finches.com": rosterTestMember("E4", "arthur", "arthur@finches.com"),
+	}
+	rows := []rosterEngineerModel{
+		// The server lowercased the email
+		rosterRow("ben", " Ben@Finches.com"),
+		// The server still has the old name after a failed update
+		rosterRow("ada lovelace", "ada@finches.com"),
+		// Not created
+		rosterRow("dora", "dora@finches.com"),
+		rosterRow("carla", "carla@finches.com"),
+	}
+
+	want := []rosterEngineerModel{
+		rosterRow("ben", " Ben@Finches.com"),
+		rosterRow("ada", "ada@finches.com"),
+		rosterRow("carla", "carla@finches.com"),
+		// Not deleted
+		rosterRow("arthur", "arthur@finches.com"),
+	}
+	if got := rosterRows(rows, members); !reflect.DeepEqual(got, want) {
+		t.Errorf("rosterRows() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffRoster(t *testing.T) {
+	current := map[string]rosterMemberModel{
+		"ada@finches.com":   rosterTestMember("E1", "ada", "ada@finches.com"),
+		"ben@finches.com":   rosterTestMember("E2", "ben", "ben@finches.com"),
+		"carla@finches.com": rosterTestMember("E3", "carla", "carla@finches.com"),
+		"dora@finches.com":  rosterTestMember("E4", "dora", "dora@finches.com"),
+	}
+	rows := []rosterEngineerModel{
+		rosterRow("ada lovelace", "ada@finches.com"),
+		rosterRow("ben", "BEN@finches.com"),
+		rosterRow("emil", "emil@finches.com"),
+	}
+
+	members, toCreate, toUpdate, toDelete := diffRoster(rows, current, false)
+
+	if want := []rosterEngineerModel{rosterRow("emil", "emil@finches.com")}; !reflect.DeepEqual(toCreate, want) {
+		t.Errorf("toCreate = %v, want %v", toCreate, want)
+	}
+	if want := []rosterEngineerModel{rosterRow("ada lovelace", "ada@finches.com")}; !reflect.DeepEqual(toUpdate, want) {
+		t.Errorf("toUpdate = %v, want %v", toUpdate, want)
+	}
+	if want := []string{"carla@finches.com", "dora@finches.com"}; !reflect.DeepEqual(toDelete, want) {
+		t.Errorf("toDelete = %v, want %v", toDelete, want)
+	}
+
+	if len(members) != len(current) {
+		t.Errorf("expected every current member to be kept until applied, got %d", len(members))
+	}
+	if got := members["ben@finches.com"].Status.ValueString(); got != rosterStatusUnchanged {
+		t.Errorf("status of ben = %q, want %q", got, rosterStatusUnchanged)
+	}
+	if got := members["ada@finches.com"].Status.ValueString(); got != rosterStatusCreated {
+		t.Errorf("status of ada = %q, want %q until updated", got, rosterStatusCreated)
+	}
+
+	// Relabelling updates every current row
+	_, _, toUpdate, _ = diffRoster(rows, current, true)
+	if want := rows[:2]; !reflect.DeepEqual(toUpdate, want) {
+		t.Errorf("relabel toUpdate = %v, want %v", toUpdate, want)
+	}
+}
+
+func TestRosterLabels(t *testing.T) {
+	current := map[string]string{"cohort": "2024", "mentor": "sloane", "track": "platform"}
+	labels := map[string]string{"cohort": "2025", "workspace": "finches"}
+
+	removed := removedLabels(map[string]string{"cohort": "2024", "track": "platform"}, labels)
+	if want := []string{"track"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removedLabels() = %v, want %v", removed, want)
+	}
+
+	want := map[string]string{"cohort": "2025", "mentor": "sloane", "workspace": "finches"}
+	if got := rosterLabels(current, labels, removed); !reflect.DeepEqual(got, want) {
+		t.Errorf("rosterLabels() = %v, want %v", got, want)
+	}
+	if got := rosterLabels(map[string]string{"track": "platform"}, nil, removed); got != nil {
+		t.Errorf("rosterLabels() = %v, want nil", got)
+	}
+}
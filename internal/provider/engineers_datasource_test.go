@@ -7,19 +7,34 @@ import (
 )
 
 func TestAccEngineersDataSource(t *testing.T) {
+	useCassette(t)
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: providerConfig + `data "devops-bootcamp_engineer" "test" {}`,
+				Config: providerConfig + `
+resource "devops-bootcamp_engineer_resource" "test" {
+  name  = "test.datasource"
+  email = "test.datasource@test.com"
+  role  = "sre"
+}
+
+data "devops-bootcamp_engineer" "test" {
+  depends_on = [devops-bootcamp_engineer_resource.test]
+}
+`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify number of coffees returned
-					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "engineer.#", "6"),
-					// Verify the first coffee to ensure all attributes are set
-					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "engineer.0.id", "G63RN"),
-					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "engineer.0.name", "sloane"),
-					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.test", "engineer.0.email", "sloane@finches.com"),
+					// Verify the engineer created by the test is listed with
+					// its attributes, whatever else the server holds
+					resource.TestCheckTypeSetElemNestedAttrs("data.devops-bootcamp_engineer.test", "engineer.*", map[string]string{
+						"name":   "test.datasource",
+						"email":  "test.datasource@test.com",
+						"role":   "sre",
+						"active": "true",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("data.devops-bootcamp_engineer.test", "engineer.*.id", "devops-bootcamp_engineer_resource.test", "id"),
 				),
 			},
 		},
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mergeLabels merges the resource labels over the provider default labels.
+// The result is unknown while the labels are, and null when empty.
+func mergeLabels(ctx context.Context, defaults map[string]string, labels types.Map) (types.Map, diag.Diagnostics) {
+	if labels.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+
+	merged := make(map[string]attr.Value, len(defaults)+len(labels.Elements()))
+	for key, value := range defaults {
+		merged[key] = types.StringValue(value)
+	}
+	for key, value := range labels.Elements() {
+		if value.IsUnknown() {
+			return types.MapUnknown(types.StringType), nil
+		}
+		merged[key] = value
+	}
+
+	if len(merged) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	return types.MapValue(types.StringType, merged)
+}
+
+// planLabelsAll sets labels_all in a create or update plan to the labels
+// merged over the provider default labels.
+func planLabelsAll(ctx context.Context, defaults map[string]string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labelsAll, diags := mergeLabels(ctx, defaults, labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+}
+
+// configuredLabels works out the labels attribute from the labels stored on
+// the server. Labels set by the resource stay, as do labels added outside of
+// Terraform so they show up as drift, but labels only coming from the
+// provider default labels are left to labels_all.
+func configuredLabels(server map[string]string, prior types.Map, defaults map[string]string) types.Map {
+	labels := make(map[string]string, len(server))
+	for key, value := range server {
+		_, configured := prior.Elements()[key]
+		if defaultValue, ok := defaults[key]; configured || !ok || defaultValue != value {
+			labels[key] = value
+		}
+	}
+
+	return labelsValue(labels)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+func labelsMap(labels map[string]string) types.Map {
+	if labels == nil {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(labels))
+	for key, value := range labels {
+		elements[key] = types.StringValue(value)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestMergeLabels(t *testing.T) {
+	defaults := map[string]string{"cohort": "2024", "workspace": "finches"}
+
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		labels   types.Map
+		want     types.Map
+	}{
+		{
+			name:   "no labels",
+			labels: labelsMap(nil),
+			want:   labelsMap(nil),
+		},
+		{
+			name:     "defaults only",
+			defaults: defaults,
+			labels:   labelsMap(nil),
+			want:     labelsMap(defaults),
+		},
+		{
+			name:     "labels override defaults",
+			defaults: defaults,
+			labels:   labelsMap(map[string]string{"cohort": "2025", "track": "platform"}),
+			want:     labelsMap(map[string]string{"cohort": "2025", "track": "platform", "workspace": "finches"}),
+		},
+		{
+			name:     "unknown labels",
+			defaults: defaults,
+			labels:   types.MapUnknown(types.StringType),
+			want:     types.MapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		got, diags := mergeLabels(context.Background(), test.defaults, test.labels)
+		if diags.HasError() {
+			t.Errorf("%s: unexpected error: %v", test.name, diags)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%s: mergeLabels() = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestConfiguredLabels(t *testing.T) {
+	defaults := map[string]string{"cohort": "2024", "workspace": "finches"}
+
+	tests := []struct {
+		name   string
+		server map[string]string
+		prior  types.Map
+		want   types.Map
+	}{
+		{
+			name:   "default labels stay in labels_all",
+			server: map[string]string{"cohort": "2024", "workspace": "finches", "track": "platform"},
+			prior:  labelsMap(map[string]string{"track": "platform"}),
+			want:   labelsMap(map[string]string{"track": "platform"}),
+		},
+		{
+			name:   "labels set to a default value stay",
+			server: map[string]string{"cohort": "2024", "workspace": "finches"},
+			prior:  labelsMap(map[string]string{"cohort": "2024"}),
+			want:   labelsMap(map[string]string{"cohort": "2024"}),
+		},
+		{
+			name:   "overridden defaults and labels added outside of terraform show",
+			server: map[string]string{"cohort": "2025", "workspace": "finches", "owner": "sloane"},
+			prior:  labelsMap(nil),
+			want:   labelsMap(map[string]string{"cohort": "2025", "owner": "sloane"}),
+		},
+		{
+			name:   "only defaults",
+			server: map[string]string{"cohort": "2024", "workspace": "finches"},
+			prior:  labelsMap(nil),
+			want:   labelsMap(nil),
+		},
+	}
+
+	for _, test := range tests {
+		got := configuredLabels(test.server, test.prior, defaults)
+		if !got.Equal(test.want) {
+			t.Errorf("%s: configuredLabels() = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAccDefaultLabels(t *testing.T) {
+	api := fakeserver.New()
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(defaults string) string {
+		return fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host           = %q
+  default_labels = %s
+}
+
+resource "devops-bootcamp_engineer_resource" "sloane" {
+  name   = "sloane"
+  email  = "sloane@finches.com"
+  labels = { cohort = "2025" }
+}
+
+resource "devops-bootcamp_dev_resource" "test" {
+  name   = "dev_finches"
+  labels = { track = "platform" }
+}
+`, server.URL, defaults)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(`{ cohort = "2024", workspace = "finches" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.sloane", "labels.%", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.sloane", "labels_all.%", "2"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.sloane", "labels_all.cohort", "2025"),
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.sloane", "labels_all.workspace", "finches"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "labels_all.%", "3"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "labels_all.cohort", "2024"),
+					checkServerDevLabels(api, map[string]string{"cohort": "2024", "workspace": "finches", "track": "platform"}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "devops-bootcamp_dev_resource.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Changing the default labels updates every resource
+			{
+				Config: config(`{ workspace = "twins" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("devops-bootcamp_engineer_resource.sloane", "labels_all.workspace", "twins"),
+					resource.TestCheckResourceAttr("devops-bootcamp_dev_resource.test", "labels_all.%", "2"),
+					checkServerDevLabels(api, map[string]string{"workspace": "twins", "track": "platform"}),
+				),
+			},
+		},
+	})
+}
+
+// checkServerDevLabels checks the labels the server holds for the only dev.
+func checkServerDevLabels(api *fakeserver.Server, want map[string]string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		devs := api.Devs()
+		if len(devs) != 1 {
+			return fmt.Errorf("expected 1 dev, got %d", len(devs))
+		}
+		if got := fmt.Sprint(devs[0].Labels); got != fmt.Sprint(want) {
+			return fmt.Errorf("expected server labels %s, got %s", fmt.Sprint(want), got)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &normalizeEmailFunction{}
+
+// NewNormalizeEmailFunction is a helper function to simplify the provider implementation.
+func NewNormalizeEmailFunction() function.Function {
+	return &normalizeEmailFunction{}
+}
+
+// normalizeEmailFunction is the function implementation.
+type normalizeEmailFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeEmailFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_email"
+}
+
+// Definition defines the function signature.
+func (f *normalizeEmailFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize an engineer email",
+		MarkdownDescription: "Trims surrounding whitespace and lower-cases an email, the form used to match engineers by email. Fails when the value is not a single `local@domain` address.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "email",
+				MarkdownDescription: "Email address to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the normalized email.
+func (f *normalizeEmailFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var email string
+
+	resp.Error = req.Arguments.Get(ctx, &email)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized := normalizeEmail(email)
+	local, domain, found := strings.Cut(normalized, "@")
+	if !found || local == "" || domain == "" || strings.Contains(domain, "@") || strings.ContainsAny(normalized, " \t\n") {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid email: "+email+" is not a single local@domain address.")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeEmailFunction(t *testing.T) {
+	tests := map[string]struct {
+		email   string
+		want    string
+		wantErr bool
+	}{
+		"already normalized": {email: "sloane@finches.com", want: "sloane@finches.com"},
+		"mixed case":         {email: "Sloane@Finches.COM", want: "sloane@finches.com"},
+		"whitespace":         {email: "  sloane@finches.com\n", want: "sloane@finches.com"},
+		"missing at":         {email: "sloane.finches.com", wantErr: true},
+		"empty local part":   {email: "@finches.com", wantErr: true},
+		"empty domain":       {email: "sloane@", wantErr: true},
+		"two at signs":       {email: "sloane@finches@com", wantErr: true},
+		"inner whitespace":   {email: "sloane o@finches.com", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := runFunction(t, NewNormalizeEmailFunction(), types.StringValue(test.email))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("expected %q, got %s", test.want, got)
+			}
+		})
+	}
+}
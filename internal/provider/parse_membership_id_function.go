@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// membershipIDSeparator joins the dev and engineer IDs of a membership ID.
+const membershipIDSeparator = "/"
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseMembershipIDFunction{}
+
+// NewParseMembershipIDFunction is a helper function to simplify the provider implementation.
+func NewParseMembershipIDFunction() function.Function {
+	return &parseMembershipIDFunction{}
+}
+
+// parseMembershipIDFunction is the function implementation.
+type parseMembershipIDFunction struct{}
+
+// membershipIDModel maps the function result.
+type membershipIDModel struct {
+	DevId      types.String `tfsdk:"dev_id"`
+	EngineerId types.String `tfsdk:"engineer_id"`
+}
+
+// Metadata returns the function name.
+func (f *parseMembershipIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_membership_id"
+}
+
+// Definition defines the function signature.
+func (f *parseMembershipIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Split a dev membership ID",
+		MarkdownDescription: "Splits a composite membership ID of the form `<dev_id>/<engineer_id>` into an object with `dev_id` and `engineer_id` attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "Membership ID to split.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"dev_id":      types.StringType,
+				"engineer_id": types.StringType,
+			},
+		},
+	}
+}
+
+// Run returns the dev and engineer IDs of the membership.
+func (f *parseMembershipIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	parts := strings.Split(id, membershipIDSeparator)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid membership ID: expected <dev_id>/<engineer_id>, got "+id+".")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, membershipIDModel{
+		DevId:      types.StringValue(parts[0]),
+		EngineerId: types.StringValue(parts[1]),
+	})
+}
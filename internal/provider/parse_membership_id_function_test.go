@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseMembershipIDFunction(t *testing.T) {
+	attributeTypes := map[string]attr.Type{
+		"dev_id":      types.StringType,
+		"engineer_id": types.StringType,
+	}
+
+	tests := map[string]struct {
+		id       string
+		dev      string
+		engineer string
+		wantErr  bool
+	}{
+		"valid":             {id: "DEV01/G63RN", dev: "DEV01", engineer: "G63RN"},
+		"missing separator": {id: "DEV01G63RN", wantErr: true},
+		"empty dev":         {id: "/G63RN", wantErr: true},
+		"empty engineer":    {id: "DEV01/", wantErr: true},
+		"too many parts":    {id: "DEV01/G63RN/X", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := runFunction(t, NewParseMembershipIDFunction(), types.StringValue(test.id))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+				"dev_id":      types.StringValue(test.dev),
+				"engineer_id": types.StringValue(test.engineer),
+			})
+			if !got.Equal(want) {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
@@ -11,12 +11,14 @@ package provider
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
 
 	// devops_resource "github.com/liatrio/devops-bootcamp/examples/ch7/devops-resources"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -29,7 +31,10 @@ import (
 // var engineer devops_resource.Engineer
 
 // Ensure devopsBootcampProvider satisfies various provider interfaces.
-var _ provider.Provider = &devopsBootcampProvider{}
+var (
+	_ provider.Provider              = &devopsBootcampProvider{}
+	_ provider.ProviderWithFunctions = &devopsBootcampProvider{}
+)
 
 // devopsBootcampProvider defines the provider implementation.
 type devopsBootcampProvider struct {
@@ -47,7 +52,29 @@ func (p *devopsBootcampProvider) Metadata(ctx context.Context, req provider.Meta
 // devopsBootcampProviderModel maps provider schema data to a Go type.
 // uses struct types with tfsdk struct field tags to map schema definitions to Go types with the actual data
 type devopsBootcampProviderModel struct {
-	Host types.String `tfsdk:"host"`
+	Host              types.String  `tfsdk:"host"`
+	Hosts             types.List    `tfsdk:"hosts"`
+	ReadHosts         types.List    `tfsdk:"read_hosts"`
+	Parallelism       types.Int64   `tfsdk:"parallelism"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	ReadCacheTTL      types.String  `tfsdk:"read_cache_ttl"`
+	VerifyConnection  types.Bool    `tfsdk:"verify_connection"`
+	BreakerThreshold  types.Int64   `tfsdk:"circuit_breaker_threshold"`
+	BreakerCooldown   types.String  `tfsdk:"circuit_breaker_cooldown"`
+	DefaultLabels     types.Map     `tfsdk:"default_labels"`
+	MaxTeams          types.Int64   `tfsdk:"max_teams_per_engineer"`
+	ReadOnly          types.Bool    `tfsdk:"read_only"`
+}
+
+// providerData is made available to resources and data sources when they
+// are configured.
+type providerData struct {
+	Client *client.Client
+	// DefaultLabels are merged into the labels of every resource with labels.
+	DefaultLabels map[string]string
+	// MaxTeamsPerEngineer limits how many devs an engineer can be in, or 0
+	// for no limit.
+	MaxTeamsPerEngineer int
 }
 
 // user defines the endpoint value when declaring this provider in the TF configuration
@@ -55,8 +82,65 @@ func (p *devopsBootcampProvider) Schema(ctx context.Context, req provider.Schema
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Bootcamp endpoint -- host of the app!!!",
-				Required:            true,
+				MarkdownDescription: "Bootcamp endpoint -- host of the app!!! Defaults to the `HOST` environment variable. Conflicts with `hosts`. " +
+					"An API listening on a Unix domain socket is reached with `unix:///path/to/sock`, or `http+unix://%2Fpath%2Fto%2Fsock/base/path` to add a base path.",
+				Optional: true,
+			},
+			"hosts": schema.ListAttribute{
+				MarkdownDescription: "Bootcamp endpoints in priority order, such as a primary and its standby. " +
+					"Requests stick to the host that last answered and fail over to the next one when it is unreachable or unavailable. " +
+					"A host failing 3 requests in a row is skipped for 30 seconds. Conflicts with `host`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"read_hosts": schema.ListAttribute{
+				MarkdownDescription: "Read replicas of the API. GET requests are sent to them first, falling back to `host` or `hosts`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of concurrent API requests used when resolving and attaching engineers. Defaults to 4.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum average number of API requests per second, shared by every resource and data source. " +
+					"The rate is lowered automatically when the server reports its quota through `X-RateLimit-*` headers or 429 responses. Requests fail rather than wait more than 10 seconds for the quota to reset. Unlimited when not set.",
+				Optional: true,
+			},
+			"read_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long GET responses are cached and shared across resources and data sources, as a Go duration such as `30s`. " +
+					"Writes invalidate the affected entries. Caching is disabled when not set.",
+				Optional: true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive requests failing to reach the API after which every request fails immediately with an \"API unavailable\" error, " +
+					"instead of each resource waiting out the request timeout. `0` disables the circuit breaker. Defaults to 3.",
+				Optional: true,
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: "How long the circuit breaker fails requests before probing the API again, as a Go duration such as `30s`. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"default_labels": schema.MapAttribute{
+				MarkdownDescription: "Labels merged into the `labels` of every engineer and dev, such as the cohort or workspace name. " +
+					"Labels set on a resource override the default label with the same key. The merged labels are shown in each resource's `labels_all`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_teams_per_engineer": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of devs an engineer can be in. Plans adding an engineer to a dev fail when the engineer is already in this many other devs. " +
+					"Only memberships already on the server are counted, not ones planned for other devs in the same run. Unlimited when not set.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Only read from the API. Plans that would create, update or destroy a resource fail, and the client refuses any request that is not a GET. " +
+					"Data sources keep working. Defaults to `false`.",
+				Optional: true,
+			},
+			"verify_connection": schema.BoolAttribute{
+				MarkdownDescription: "Contact the API when the provider is configured, so a wrong `host` fails before any resource is changed. " +
+					"The server's API version is recorded, and resources report features the server lacks instead of failing mid-apply. Defaults to `false`.",
+				Optional: true,
 			},
 		},
 	}
@@ -90,6 +174,122 @@ func (p *devopsBootcampProvider) Configure(ctx context.Context, req provider.Con
 		)
 	}
 
+	for _, hostList := range []struct {
+		name  string
+		hosts types.List
+	}{{"hosts", config.Hosts}, {"read_hosts", config.ReadHosts}} {
+		if hostList.hosts.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(hostList.name),
+				"Unknown DevOps Bootcamp Hosts",
+				"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp "+hostList.name+". "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			continue
+		}
+		for index, host := range hostList.hosts.Elements() {
+			if host.IsUnknown() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(hostList.name).AtListIndex(index),
+					"Unknown DevOps Bootcamp Host",
+					"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for a DevOps Bootcamp host. "+
+						"Either target apply the source of the value first or set the value statically in the configuration.",
+				)
+			}
+		}
+	}
+
+	if config.Parallelism.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Unknown DevOps Bootcamp Parallelism",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp parallelism. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown DevOps Bootcamp Requests Per Second",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp requests per second. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ReadCacheTTL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_cache_ttl"),
+			"Unknown DevOps Bootcamp Read Cache TTL",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp read cache TTL. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.BreakerThreshold.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_threshold"),
+			"Unknown DevOps Bootcamp Circuit Breaker Threshold",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp circuit breaker threshold. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.BreakerCooldown.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_cooldown"),
+			"Unknown DevOps Bootcamp Circuit Breaker Cooldown",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp circuit breaker cooldown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_labels"),
+			"Unknown DevOps Bootcamp Default Labels",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp default labels. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	for key, value := range config.DefaultLabels.Elements() {
+		if value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_labels").AtMapKey(key),
+				"Unknown DevOps Bootcamp Default Labels",
+				"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp default label "+key+". "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+
+	if config.MaxTeams.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_teams_per_engineer"),
+			"Unknown DevOps Bootcamp Max Teams Per Engineer",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp max teams per engineer. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown DevOps Bootcamp Read Only",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp read only mode. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.VerifyConnection.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("verify_connection"),
+			"Unknown DevOps Bootcamp Verify Connection",
+			"The provider cannot create the DevOps Bootcamp client as there is an unknown configuration value for the DevOps Bootcamp verify connection. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -102,6 +302,19 @@ func (p *devopsBootcampProvider) Configure(ctx context.Context, req provider.Con
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
+
+	hosts := hostValues(config.Hosts)
+	readHosts := hostValues(config.ReadHosts)
+	if len(hosts) > 0 {
+		if !config.Host.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hosts"),
+				"Conflicting DevOps Bootcamp Hosts",
+				"The provider cannot create the DevOps Bootcamp client as both host and hosts are set. Move host into hosts.",
+			)
+		}
+		host = hosts[0]
+	}
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -115,6 +328,83 @@ func (p *devopsBootcampProvider) Configure(ctx context.Context, req provider.Con
 		)
 	}
 
+	for _, hostList := range []struct {
+		name  string
+		hosts []string
+	}{{"host", []string{host}}, {"hosts", hosts}, {"read_hosts", readHosts}} {
+		for _, value := range hostList.hosts {
+			if err := client.ValidateHost(value); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(hostList.name),
+					"Invalid DevOps Bootcamp Host",
+					"The provider cannot create the DevOps Bootcamp client as "+err.Error()+". "+
+						"Unix socket hosts look like unix:///path/to/sock or http+unix://%2Fpath%2Fto%2Fsock.",
+				)
+			}
+		}
+	}
+
+	if !config.Parallelism.IsNull() && config.Parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid DevOps Bootcamp Parallelism",
+			"The provider cannot create the DevOps Bootcamp client as parallelism must be at least 1.",
+		)
+	}
+
+	if !config.RequestsPerSecond.IsNull() && config.RequestsPerSecond.ValueFloat64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid DevOps Bootcamp Requests Per Second",
+			"The provider cannot create the DevOps Bootcamp client as requests_per_second must be greater than 0.",
+		)
+	}
+
+	var readCacheTTL time.Duration
+	if !config.ReadCacheTTL.IsNull() {
+		var err error
+		readCacheTTL, err = time.ParseDuration(config.ReadCacheTTL.ValueString())
+		if err != nil || readCacheTTL <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_cache_ttl"),
+				"Invalid DevOps Bootcamp Read Cache TTL",
+				"The provider cannot create the DevOps Bootcamp client as read_cache_ttl must be a positive duration such as \"30s\".",
+			)
+		}
+	}
+
+	if !config.BreakerThreshold.IsNull() && config.BreakerThreshold.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_threshold"),
+			"Invalid DevOps Bootcamp Circuit Breaker Threshold",
+			"The provider cannot create the DevOps Bootcamp client as circuit_breaker_threshold must not be negative.",
+		)
+	}
+
+	if !config.MaxTeams.IsNull() && config.MaxTeams.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_teams_per_engineer"),
+			"Invalid DevOps Bootcamp Max Teams Per Engineer",
+			"The provider cannot create the DevOps Bootcamp client as max_teams_per_engineer must be at least 1.",
+		)
+	}
+
+	breakerCooldown := client.DefaultBreakerCooldown
+	if !config.BreakerCooldown.IsNull() {
+		var err error
+		breakerCooldown, err = time.ParseDuration(config.BreakerCooldown.ValueString())
+		if err != nil || breakerCooldown <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_cooldown"),
+				"Invalid DevOps Bootcamp Circuit Breaker Cooldown",
+				"The provider cannot create the DevOps Bootcamp client as circuit_breaker_cooldown must be a positive duration such as \"30s\".",
+			)
+		}
+	}
+
+	var defaultLabels map[string]string
+	resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -124,20 +414,92 @@ func (p *devopsBootcampProvider) Configure(ctx context.Context, req provider.Con
 	tflog.Debug(ctx, "Creating devops-bootcamp client")
 
 	// Create a new DevOps API client using the configuration values
-	client := client.NewClient(host)
+	apiClient := client.NewClient(host)
+	apiClient.ReadOnly = config.ReadOnly.ValueBool()
+	if !config.Parallelism.IsNull() {
+		apiClient.Parallelism = int(config.Parallelism.ValueInt64())
+	}
+	if !config.RequestsPerSecond.IsNull() {
+		apiClient.RateLimiter = client.NewRateLimiter(config.RequestsPerSecond.ValueFloat64())
+	}
+	if readCacheTTL > 0 {
+		apiClient.EnableReadCache(readCacheTTL)
+	}
+	breakerThreshold := client.DefaultBreakerThreshold
+	if !config.BreakerThreshold.IsNull() {
+		breakerThreshold = int(config.BreakerThreshold.ValueInt64())
+	}
+	apiClient.SetCircuitBreaker(breakerThreshold, breakerCooldown)
+	if len(hosts) > 1 || len(readHosts) > 0 {
+		if len(hosts) == 0 {
+			hosts = []string{host}
+		}
+		apiClient.EnableFailover(hosts, readHosts)
+	}
+
+	if config.VerifyConnection.ValueBool() {
+		server, err := apiClient.WithContext(ctx).Verify()
+		if err != nil {
+			summary := "Unable to Connect to DevOps Bootcamp API"
+			detail := "The provider could not reach the DevOps Bootcamp API at " + host + ": " + err.Error()
+			switch {
+			case len(readHosts) > 0:
+				// Reads are served by read_hosts before falling back to
+				// hosts, so no single attribute supplied the endpoint.
+				resp.Diagnostics.AddError(summary, "The provider could not reach the DevOps Bootcamp API at any of its hosts: "+err.Error())
+			case len(hosts) > 0:
+				resp.Diagnostics.AddAttributeError(path.Root("hosts"), summary, detail)
+			case !config.Host.IsNull():
+				resp.Diagnostics.AddAttributeError(path.Root("host"), summary, detail)
+			default:
+				// The host came from the HOST environment variable.
+				resp.Diagnostics.AddError(summary, detail)
+			}
+			return
+		}
+		apiClient.Server = server
+
+		if server.Version == "" {
+			resp.Diagnostics.AddWarning(
+				"Unknown DevOps Bootcamp API Version",
+				"The DevOps Bootcamp API at "+host+" does not report its version. "+
+					"The provider assumes it supports every feature.",
+			)
+		} else {
+			tflog.Info(ctx, "Connected to devops-bootcamp api", map[string]interface{}{"version": server.Version, "features": server.Features})
+		}
+	}
 
 	// Make the DevOps client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerData{
+		Client:              apiClient,
+		DefaultLabels:       defaultLabels,
+		MaxTeamsPerEngineer: int(config.MaxTeams.ValueInt64()),
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "Configured devops-bootcamp client", map[string]interface{}{"success": true})
 }
 
+// hostValues returns the non-empty hosts of a hosts list.
+func hostValues(values types.List) []string {
+	var hosts []string
+	for _, value := range values.Elements() {
+		if host, ok := value.(types.String); ok && host.ValueString() != "" {
+			hosts = append(hosts, host.ValueString())
+		}
+	}
+
+	return hosts
+}
+
 func (p *devopsBootcampProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewEngineerResource,
 		NewDevResource,
+		NewEngineerRosterResource,
 	}
 }
 
@@ -148,6 +510,14 @@ func (p *devopsBootcampProvider) DataSources(ctx context.Context) []func() datas
 	}
 }
 
+func (p *devopsBootcampProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeEmailFunction,
+		NewParseMembershipIDFunction,
+		NewEngineerHandleFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &devopsBootcampProvider{
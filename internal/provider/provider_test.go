@@ -1,8 +1,26 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
 )
 
 const (
@@ -27,3 +45,172 @@ var (
 		"devops-bootcamp": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// useCassette records the test's API traffic to, or replays it from,
+// testdata/cassettes/<test name>.json when DEVOPS_BOOTCAMP_CASSETTE_MODE is
+// set, so tests written against the live API also run offline.
+func useCassette(t *testing.T) {
+	t.Helper()
+	if os.Getenv(client.CassetteModeEnv) == "" {
+		return
+	}
+
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	t.Setenv(client.CassetteEnv, path)
+	t.Cleanup(func() { client.CloseCassette(path) })
+}
+
+// runFunction calls a provider function with the given arguments the way the
+// framework does and returns its result and error.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	returnType := definition.Definition.Return.GetType()
+	unknown, err := returnType.ValueFromTerraform(ctx, tftypes.NewValue(returnType.TerraformType(ctx), tftypes.UnknownValue))
+	if err != nil {
+		t.Fatalf("unexpected error building the result: %s", err)
+	}
+
+	resp := function.RunResponse{Result: function.NewResultData(unknown)}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func TestAccProviderVerifyConnection(t *testing.T) {
+	server := httptest.NewServer(fakeserver.New())
+	defer server.Close()
+	// Nothing listens on the closed server's address
+	closed := httptest.NewServer(fakeserver.New())
+	closed.Close()
+
+	config := `
+provider "devops-bootcamp" {
+  host              = %q
+  verify_connection = true
+}
+
+data "devops-bootcamp_engineer" "all" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(config, closed.URL),
+				ExpectError: regexp.MustCompile("Unable to Connect to DevOps Bootcamp API"),
+			},
+			// Last, so the provider can be configured to destroy the test
+			{
+				Config: fmt.Sprintf(config, server.URL),
+				Check:  resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.all", "engineer.#", "0"),
+			},
+		},
+	})
+}
+
+// configureProvider configures the provider with the given attribute values,
+// leaving every other attribute null.
+func configureProvider(t *testing.T, values map[string]tftypes.Value) provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+	}
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, req, &resp)
+
+	return resp
+}
+
+func TestProviderConfigureUnknownHosts(t *testing.T) {
+	hostsType := tftypes.List{ElementType: tftypes.String}
+
+	for _, name := range []string{"hosts", "read_hosts"} {
+		resp := configureProvider(t, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "http://localhost:8080"),
+			name:   tftypes.NewValue(hostsType, tftypes.UnknownValue),
+		})
+
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Fatalf("%s: expected a single error, got %v", name, resp.Diagnostics)
+		}
+		diagnostic, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+		if !ok || !diagnostic.Path().Equal(path.Root(name)) || diagnostic.Summary() != "Unknown DevOps Bootcamp Hosts" {
+			t.Errorf("%s: unexpected error %v", name, resp.Diagnostics.Errors()[0])
+		}
+	}
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"hosts": tftypes.NewValue(hostsType, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "http://localhost:8080"),
+			tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	})
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unknown DevOps Bootcamp Host" {
+		t.Errorf("expected an unknown host error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureVerifyConnectionPath(t *testing.T) {
+	// Nothing listens on the closed server's address
+	closed := httptest.NewServer(fakeserver.New())
+	closed.Close()
+	hostsType := tftypes.List{ElementType: tftypes.String}
+	closedHosts := tftypes.NewValue(hostsType, []tftypes.Value{tftypes.NewValue(tftypes.String, closed.URL)})
+
+	tests := map[string]struct {
+		values map[string]tftypes.Value
+		path   path.Path
+	}{
+		"host": {
+			values: map[string]tftypes.Value{"host": tftypes.NewValue(tftypes.String, closed.URL)},
+			path:   path.Root("host"),
+		},
+		"hosts": {
+			values: map[string]tftypes.Value{"hosts": closedHosts},
+			path:   path.Root("hosts"),
+		},
+		// Reads may come from read_hosts or hosts
+		"read_hosts": {
+			values: map[string]tftypes.Value{"hosts": closedHosts, "read_hosts": closedHosts},
+		},
+	}
+
+	for name, test := range tests {
+		test.values["verify_connection"] = tftypes.NewValue(tftypes.Bool, true)
+		resp := configureProvider(t, test.values)
+
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Unable to Connect to DevOps Bootcamp API" {
+			t.Fatalf("%s: expected a connection error, got %v", name, resp.Diagnostics)
+		}
+		diagnostic, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+		if len(test.path.Steps()) == 0 {
+			if ok {
+				t.Errorf("%s: expected no attribute path, got %s", name, diagnostic.Path())
+			}
+			continue
+		}
+		if !ok || !diagnostic.Path().Equal(test.path) {
+			t.Errorf("%s: expected the error on %s, got %v", name, test.path, resp.Diagnostics.Errors()[0])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+)
+
+// checkReadOnly fails a plan that would create, update or destroy a resource
+// while the provider is read only, so the refusal shows up at plan time
+// rather than as a client error mid-apply. It compares the final plan in resp
+// with the state, so it runs after the rest of ModifyPlan has planned values
+// such as labels_all. It reports whether the plan failed.
+func checkReadOnly(c *client.Client, typeName string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	if c == nil || !c.ReadOnly {
+		return false
+	}
+
+	var action string
+	switch {
+	case req.State.Raw.IsNull():
+		action = "created"
+	case resp.Plan.Raw.IsNull():
+		action = "destroyed"
+	case !resp.Plan.Raw.Equal(req.State.Raw):
+		action = "updated"
+	default:
+		return false
+	}
+
+	resp.Diagnostics.AddError(
+		"Provider is read only",
+		"The "+typeName+" cannot be "+action+" because the provider is configured with read_only = true. "+
+			"Set read_only to false to change DevOps Bootcamp resources.",
+	)
+	return true
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+func TestAccProviderReadOnly(t *testing.T) {
+	api := fakeserver.New()
+	api.AddEngineer(client.Engineer{Name: "theo", Email: "theo@finches.com"})
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(readOnly bool, name, cohort string, resources bool) string {
+		config := fmt.Sprintf(`
+provider "devops-bootcamp" {
+  host           = %q
+  read_only      = %t
+  default_labels = { cohort = %q }
+}
+
+data "devops-bootcamp_engineer" "all" {}
+
+data "devops-bootcamp_devs" "all" {}
+`, server.URL, readOnly, cohort)
+		if !resources {
+			return config
+		}
+		return config + fmt.Sprintf(`
+resource "devops-bootcamp_engineer_resource" "sloane" {
+  name  = %q
+  email = "sloane@finches.com"
+}
+
+resource "devops-bootcamp_dev_resource" "test" {
+  name          = "dev_finches"
+  force_destroy = true
+  engineers     = [{ id = devops-bootcamp_engineer_resource.sloane.id }]
+}
+`, name)
+	}
+
+	// writes records the server writes once the resources exist, so the
+	// read only steps can check none of them reached the server.
+	var writes int
+	checkNoWrites := func() {
+		if got := api.Writes(); got != writes {
+			t.Errorf("expected %d writes to reach the server, got %d", writes, got)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Once writes are allowed again everything the test created is gone
+		CheckDestroy: func(_ *terraform.State) error {
+			if devs := api.Devs(); len(devs) != 0 {
+				return fmt.Errorf("expected every dev to be destroyed, got %d", len(devs))
+			}
+			if engineers := api.Engineers(); len(engineers) != 1 {
+				return fmt.Errorf("expected only the seeded engineer to be left, got %d", len(engineers))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Data sources read while the provider is read only
+			{
+				Config: config(true, "sloane", "finches", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.all", "engineer.#", "1"),
+					resource.TestCheckResourceAttr("data.devops-bootcamp_engineer.all", "engineer.0.name", "theo"),
+				),
+			},
+			// Creating fails at plan time
+			{
+				PreConfig:   checkNoWrites,
+				Config:      config(true, "sloane", "finches", true),
+				ExpectError: regexp.MustCompile(`Provider is read only`),
+			},
+			// Create with writes allowed
+			{
+				PreConfig: checkNoWrites,
+				Config:    config(false, "sloane", "finches", true),
+				Check: func(_ *terraform.State) error {
+					writes = api.Writes()
+					return nil
+				},
+			},
+			// Switching to read only with nothing to change still plans
+			{
+				Config: config(true, "sloane", "finches", true),
+			},
+			// Changing only the provider default labels fails at plan time
+			{
+				PreConfig:   checkNoWrites,
+				Config:      config(true, "sloane", "sparrows", true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Provider is read only`),
+			},
+			// Updating fails
+			{
+				PreConfig:   checkNoWrites,
+				Config:      config(true, "sloane_finch", "finches", true),
+				ExpectError: regexp.MustCompile(`Provider is read only`),
+			},
+			// Destroying fails
+			{
+				PreConfig:   checkNoWrites,
+				Config:      config(true, "sloane", "finches", false),
+				ExpectError: regexp.MustCompile(`Provider is read only`),
+			},
+			// Allow writes again so the resources can be destroyed
+			{
+				PreConfig: checkNoWrites,
+				Config:    config(false, "sloane", "finches", true),
+			},
+		},
+	})
+}
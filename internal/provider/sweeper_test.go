@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+)
+
+// defaultSweepPrefix matches the names used by the acceptance tests.
+const defaultSweepPrefix = "test"
+
+// TestMain runs the sweepers instead of the tests when -sweep is set, e.g.
+//
+//	HOST=http://localhost:8080 go test ./internal/provider -v -sweep=local
+//
+// The sweep value is required by the test framework but otherwise unused.
+// DEVOPS_BOOTCAMP_SWEEP_PREFIX overrides the name prefix of swept objects.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// Ops and devops sweepers belong here once the provider manages those objects.
+func init() {
+	resource.AddTestSweepers("devops-bootcamp_dev_resource", &resource.Sweeper{
+		Name: "devops-bootcamp_dev_resource",
+		F: func(_ string) error {
+			return sweepDevs(sweeperClient(), sweepPrefix())
+		},
+	})
+
+	// Devs are swept first so engineers are detached before they are deleted
+	resource.AddTestSweepers("devops-bootcamp_engineer_resource", &resource.Sweeper{
+		Name:         "devops-bootcamp_engineer_resource",
+		Dependencies: []string{"devops-bootcamp_dev_resource"},
+		F: func(_ string) error {
+			return sweepEngineers(sweeperClient(), sweepPrefix())
+		},
+	})
+}
+
+// sweeperClient returns a client for the server in HOST, falling back to the
+// server used by providerConfig.
+func sweeperClient() *client.Client {
+	host := os.Getenv("HOST")
+	if host == "" {
+		host = "http://localhost:8080"
+	}
+
+	return client.NewClient(host)
+}
+
+func sweepPrefix() string {
+	if prefix := os.Getenv("DEVOPS_BOOTCAMP_SWEEP_PREFIX"); prefix != "" {
+		return prefix
+	}
+
+	return defaultSweepPrefix
+}
+
+// sweepDevs deletes every dev whose name starts with prefix.
+func sweepDevs(c *client.Client, prefix string) error {
+	devs, err := c.GetDevs()
+	if err != nil {
+		return fmt.Errorf("listing devs: %w", err)
+	}
+
+	var errs []error
+	for _, dev := range devs {
+		if !strings.HasPrefix(dev.Name, prefix) {
+			continue
+		}
+		if err := c.DeleteDev(dev.Id); err != nil {
+			errs = append(errs, fmt.Errorf("deleting dev %s (%s): %w", dev.Id, dev.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sweepEngineers deletes every engineer whose name starts with prefix.
+func sweepEngineers(c *client.Client, prefix string) error {
+	engineers, err := c.GetEngineers()
+	if err != nil {
+		return fmt.Errorf("listing engineers: %w", err)
+	}
+
+	var ids []string
+	for _, engineer := range engineers {
+		if strings.HasPrefix(engineer.Name, prefix) {
+			ids = append(ids, engineer.Id)
+		}
+	}
+
+	var errs []error
+	for index, err := range c.DeleteEngineers(ids) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("deleting engineer %s: %w", ids[index], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func TestSweepers(t *testing.T) {
+	api := fakeserver.New()
+	kept := api.AddEngineer(client.Engineer{Name: "sloane", Email: "sloane@finches.com"})
+	leaked := api.AddEngineer(client.Engineer{Name: "test.edit", Email: "test.edit@test.com"})
+	api.AddDev(client.Dev{Name: "dev_finches"}, kept.Id)
+	api.AddDev(client.Dev{Name: "test_dev"}, kept.Id, leaked.Id)
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+	c := client.NewClient(server.URL)
+
+	if err := sweepDevs(c, defaultSweepPrefix); err != nil {
+		t.Fatal(err)
+	}
+	if err := sweepEngineers(c, defaultSweepPrefix); err != nil {
+		t.Fatal(err)
+	}
+
+	engineers := api.Engineers()
+	if len(engineers) != 1 || engineers[0].Id != kept.Id {
+		t.Errorf("expected only %s to be left, got %+v", kept.Id, engineers)
+	}
+	devs := api.Devs()
+	if len(devs) != 1 || devs[0].Name != "dev_finches" {
+		t.Errorf("expected only dev_finches to be left, got %+v", devs)
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans of provider operations. Without a
+// configured OpenTelemetry tracer provider the spans are no-ops.
+const tracerName = "github.com/hashicorp/terraform-provider-scaffolding-framework/internal/provider"
+
+// startSpan starts a span for an operation on a resource or data source,
+// such as "devops-bootcamp_dev_resource.Create". Requests the client sends
+// with the returned context become its children.
+func startSpan(ctx context.Context, typeName, operation, entityType string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, typeName+"."+operation,
+		trace.WithAttributes(
+			attribute.String("devops_bootcamp.entity.type", entityType),
+			attribute.String("devops_bootcamp.operation", operation),
+		),
+	)
+}
+
+// setSpanEntityID records the ID of the entity an operation works on.
+func setSpanEntityID(span trace.Span, id string) {
+	if id != "" {
+		span.SetAttributes(attribute.String("devops_bootcamp.entity.id", id))
+	}
+}
+
+// endSpan records whether the operation produced errors and ends its span.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	status := "ok"
+	if diags.HasError() {
+		status = "error"
+		errs := diags.Errors()
+		span.SetStatus(codes.Error, errs[0].Summary()+": "+errs[0].Detail())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.SetAttributes(attribute.String("devops_bootcamp.status", status))
+	span.End()
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/client"
+	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/fakeserver"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOperationSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(previous)
+
+	api := fakeserver.New()
+	api.AddEngineer(client.Engineer{Name: "sloane", Email: "sloane@finches.com"})
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	ctx := context.Background()
+	d := &engineerDataSource{client: client.NewClient(server.URL)}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	d.Read(ctx, datasource.ReadRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	// Child spans end first
+	request, operation := spans[0], spans[1]
+	if operation.Name != "devops-bootcamp_engineer.Read" {
+		t.Errorf("unexpected operation span %q", operation.Name)
+	}
+	if request.Parent.SpanID() != operation.SpanContext.SpanID() {
+		t.Errorf("request span %q is not a child of the operation span", request.Name)
+	}
+	if operation.Status.Code != codes.Ok {
+		t.Errorf("operation span status = %v, want ok", operation.Status.Code)
+	}
+
+	want := []attribute.KeyValue{
+		attribute.String("devops_bootcamp.entity.type", "engineer"),
+		attribute.String("devops_bootcamp.operation", "Read"),
+		attribute.String("devops_bootcamp.status", "ok"),
+	}
+	for _, kv := range want {
+		found := false
+		for _, got := range operation.Attributes {
+			found = found || got == kv
+		}
+		if !found {
+			t.Errorf("operation span is missing %s=%s", kv.Key, kv.Value.Emit())
+		}
+	}
+}
@@ -7,9 +7,15 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-provider-scaffolding-framework/internal/provider"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -45,9 +51,45 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
 
+	shutdown, err := setupTracing(ctx)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	if shutdownErr := shutdown(ctx); shutdownErr != nil {
+		log.Printf("Error flushing traces: %s", shutdownErr)
+	}
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// setupTracing exports the provider's OpenTelemetry spans over OTLP when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set. The exporter reads the rest of its
+// configuration from the standard OTEL_EXPORTER_OTLP_* variables. Without
+// an endpoint spans stay no-ops. The returned function flushes the spans.
+func setupTracing(ctx context.Context) (func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-devops-bootcamp"),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
 }